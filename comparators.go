@@ -1,63 +1,186 @@
 package semver
 
+import "strings"
+
 // Compare accepts a Version and compares itself against it, returning >0 for greater than, 0 for equals and <0 for less than.
 func (v *Version) Compare(other *Version) int {
-	if v.major != other.major {
-		if v.major > other.major {
-			return 1
+	// This is compareNumbers without the index, written out as Compare is called so often.
+	// Numbers beyond math.MaxUint64 read as math.MaxUint64, so different majors still decide.
+	switch {
+	case v.major != other.major:
+		return compareUint(v.major, other.major)
+	case len(v.large) != 0 || len(other.large) != 0:
+		if c, _ := v.compareLarge(other); c != 0 {
+			return c
 		}
-		return -1
+	case v.minor != other.minor:
+		return compareUint(v.minor, other.minor)
+	case v.patch != other.patch:
+		return compareUint(v.patch, other.patch)
 	}
 
-	if v.minor != other.minor {
-		if v.minor > other.minor {
+	if len(v.prerelease) == 0 || len(other.prerelease) == 0 {
+		switch {
+		case len(v.prerelease) == len(other.prerelease):
+			return 0
+		case len(v.prerelease) == 0:
 			return 1
 		}
 		return -1
 	}
 
-	if v.patch != other.patch {
-		if v.patch > other.patch {
-			return 1
-		}
+	return comparePrerelease(v.prerelease, other.prerelease)
+}
+
+// Equal reports whether v and other have the same precedence, ignoring their metadata as Compare does.
+func (v *Version) Equal(other *Version) bool {
+	return v.Compare(other) == 0
+}
+
+// StrictEqual reports whether v and other are identical, including their metadata.
+func (v *Version) StrictEqual(other *Version) bool {
+	return *v == *other
+}
+
+// CompareBuild is like Compare, but orders versions of equal precedence by their metadata, matching the
+// behaviour of npm's semver.compareBuild. A version without metadata comes first, and metadata identifiers
+// are compared like prerelease identifiers. It is a total order, returning 0 only if StrictEqual does.
+func (v *Version) CompareBuild(other *Version) int {
+	if c := v.Compare(other); c != 0 {
+		return c
+	}
+	switch {
+	case v.metadata == other.metadata:
+		return 0
+	case v.metadata == "":
 		return -1
+	case other.metadata == "":
+		return 1
 	}
+	return comparePrerelease(v.metadata, other.metadata)
+}
 
-	if v.prerelease == nil {
-		if other.prerelease == nil {
-			return 0
-		}
-		return 1
+// compareNumbers compares the major, minor and patch versions of v and other, returning the
+// result and the index of the first number that differs, which is 3 if they are all equal.
+func (v *Version) compareNumbers(other *Version) (int, int) {
+	if len(v.large) != 0 || len(other.large) != 0 {
+		return v.compareLarge(other)
 	}
-	if other.prerelease == nil {
-		return -1
+	switch {
+	case v.major != other.major:
+		return compareUint(v.major, other.major), 0
+	case v.minor != other.minor:
+		return compareUint(v.minor, other.minor), 1
+	case v.patch != other.patch:
+		return compareUint(v.patch, other.patch), 2
 	}
+	return 0, 3
+}
 
-	return v.prerelease.compare(other.prerelease)
+// compareLarge is compareNumbers for numbers beyond math.MaxUint64, which are only known in decimal form.
+func (v *Version) compareLarge(other *Version) (int, int) {
+	a, b := v.decimals(), other.decimals()
+	for i := range a {
+		if c := compareDecimal(a[i], b[i]); c != 0 {
+			return c, i
+		}
+	}
+	return 0, 3
 }
 
-func (p *prereleases) compare(other *prereleases) int {
-	for i := 0; i < len(p.values) && i < len(other.values); i++ {
-		if p.values[i] != other.values[i] {
-			if val1 := p.numbers[i]; val1 > 0 {
-				if val2 := other.numbers[i]; val2 > 0 {
-					if val1 > val2 {
-						return 1
+func compareUint(a, b uint64) int {
+	if a > b {
+		return 1
+	}
+	return -1
+}
+
+// comparePrerelease compares two dot separated sets of prerelease identifiers in a single pass.
+// The identifiers before the first byte that differs are equal, so only the identifiers holding it
+// matter, and that byte decides between them unless one of them is numerical.
+func comparePrerelease(a, b string) int {
+	if a == b {
+		return 0
+	}
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	k := 0
+	for k < n && a[k] == b[k] {
+		k++
+	}
+
+	// An identifier sorts before the identifiers it is the start of, numerical or not,
+	// and so do sets of identifiers.
+	switch {
+	case k == len(a):
+		return -1
+	case k == len(b):
+		return 1
+	case a[k] == '.':
+		return -1
+	case b[k] == '.':
+		return 1
+	}
+	if isDigit(a[k]) || isDigit(b[k]) {
+		start := k
+		for start > 0 && isDigit(a[start-1]) {
+			start--
+		}
+		if start == 0 || a[start-1] == '.' {
+			// The identifiers start with the same digits, if any, so they may be numerical.
+			da, db := skipDigits(a, k), skipDigits(b, k)
+			numa, numb := da == len(a) || a[da] == '.', db == len(b) || b[db] == '.'
+			switch {
+			case numa && numb:
+				// Only metadata identifiers may have leading zeroes. Those of equal value compare as text.
+				if a[start] == '0' || b[start] == '0' {
+					if c := compareDecimal(strings.TrimLeft(a[start:da], "0"), strings.TrimLeft(b[start:db], "0")); c != 0 {
+						return c
 					}
-					return -1
+				} else if da != db {
+					return compareUint(uint64(da), uint64(db))
 				}
-			}
-			if p.values[i] > other.values[i] {
+			case numa:
+				return -1
+			case numb:
 				return 1
 			}
-			return -1
 		}
 	}
+	return compareUint(uint64(a[k]), uint64(b[k]))
+}
 
-	if len(p.values) == len(other.values) {
-		return 0
-	} else if len(p.values) < len(other.values) {
+// skipDigits returns the index of the first byte from i on in s that isn't a digit, or len(s).
+func skipDigits(s string, i int) int {
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return i
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func numerical(ident string) bool {
+	for i := 0; i < len(ident); i++ {
+		if !isDigit(ident[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// compareDecimal compares two decimal numbers of any length without leading zeroes.
+func compareDecimal(a, b string) int {
+	// Without leading zeroes, a longer number is a larger number.
+	switch {
+	case len(a) > len(b):
+		return 1
+	case len(a) < len(b):
 		return -1
 	}
-	return 1
+	return strings.Compare(a, b)
 }
@@ -0,0 +1,68 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors returned by New, SetPrerelease, SetMetadata and ParseRange wrap one of these,
+// so they can be told apart with errors.Is.
+var (
+	ErrInvalidCharacter = errors.New("invalid character")
+	ErrLeadingZero      = errors.New("leading zero")
+	ErrEmptyIdentifier  = errors.New("empty identifier")
+	ErrMissingComponent = errors.New("missing component")
+	ErrUnexpectedEnd    = errors.New("unexpected end of input")
+)
+
+// ErrUnderflow is returned when decrementing a version number that is already 0.
+var ErrUnderflow = errors.New("number below zero")
+
+// ErrorKind classifies a ParseError.
+type ErrorKind int
+
+const (
+	InvalidCharacter ErrorKind = iota // character not allowed at this position
+	LeadingZero                       // numeric component or identifier starting with 0
+	EmptyIdentifier                   // empty prerelease or metadata identifier
+	MissingComponent                  // major, minor or patch version not given
+	UnexpectedEnd                     // input ended before the version or range was complete
+)
+
+var kinds = []error{
+	InvalidCharacter: ErrInvalidCharacter,
+	LeadingZero:      ErrLeadingZero,
+	EmptyIdentifier:  ErrEmptyIdentifier,
+	MissingComponent: ErrMissingComponent,
+	UnexpectedEnd:    ErrUnexpectedEnd,
+}
+
+func (k ErrorKind) String() string {
+	if k < 0 || int(k) >= len(kinds) {
+		return fmt.Sprintf("ErrorKind(%d)", int(k))
+	}
+	return kinds[k].Error()
+}
+
+// ParseError describes why a version or range string could not be parsed.
+type ParseError struct {
+	Input  string    // the string being parsed
+	Offset int       // byte offset of Token in Input
+	Token  string    // the offending part of Input
+	Kind   ErrorKind // what went wrong
+}
+
+func (e *ParseError) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("%v at offset %d in %q", e.Kind, e.Offset, e.Input)
+	}
+	return fmt.Sprintf("%v %q at offset %d in %q", e.Kind, e.Token, e.Offset, e.Input)
+}
+
+// Unwrap returns the sentinel error matching Kind, or nil if there is none.
+func (e *ParseError) Unwrap() error {
+	if e.Kind < 0 || int(e.Kind) >= len(kinds) {
+		return nil
+	}
+	return kinds[e.Kind]
+}
@@ -0,0 +1,102 @@
+package semver_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hansrodtang/semver"
+)
+
+type parseErrorTest struct {
+	input  string
+	offset int
+	token  string
+	kind   semver.ErrorKind
+}
+
+var versionErrors = []parseErrorTest{
+	{"", 0, "", semver.MissingComponent},
+	{"1.2", 3, "", semver.MissingComponent},
+	{"1..1", 2, "", semver.MissingComponent},
+	{"1.2.3.4", 5, ".", semver.InvalidCharacter},
+	{"1.2.x", 4, "x", semver.InvalidCharacter},
+	{"1.02.3", 2, "02", semver.LeadingZero},
+	{"1.2.3-beta.01", 11, "01", semver.LeadingZero},
+	{"1.2.3-beta..1", 11, "", semver.EmptyIdentifier},
+	{"1.2.3+build!", 11, "!", semver.InvalidCharacter},
+	{"1.2.3-beta+a+b", 12, "+", semver.InvalidCharacter},
+}
+
+var rangeErrors = []parseErrorTest{
+	{"~ 1.2.3", 1, " ", semver.InvalidCharacter},
+	{">=1.0.0 <01.0.0", 9, "01", semver.LeadingZero},
+	{"1.2.3 - 1.x", 8, "1.x", semver.MissingComponent},
+	{"1.2.3 ||", 8, "", semver.UnexpectedEnd},
+	{"1.2.3 || ^1.2.3-beta.01", 21, "01", semver.LeadingZero},
+}
+
+func checkParseError(t *testing.T, fn string, c parseErrorTest, err error) {
+	var perr *semver.ParseError
+	if !errors.As(err, &perr) {
+		t.Errorf("%v(%q) => %v, want *ParseError", fn, c.input, err)
+		return
+	}
+	if perr.Input != c.input || perr.Offset != c.offset || perr.Token != c.token || perr.Kind != c.kind {
+		t.Errorf("%v(%q) => %#v, want offset %d, token %q, kind %v", fn, c.input, perr, c.offset, c.token, c.kind)
+	}
+	if !errors.Is(err, perr.Unwrap()) {
+		t.Errorf("errors.Is(%v, %v) => false, want true", err, perr.Unwrap())
+	}
+}
+
+func TestVersionParseError(t *testing.T) {
+	for _, c := range versionErrors {
+		_, err := semver.New(c.input)
+		checkParseError(t, "New", c, err)
+	}
+}
+
+func TestRangeParseError(t *testing.T) {
+	for _, c := range rangeErrors {
+		_, err := semver.ParseRange(c.input)
+		checkParseError(t, "ParseRange", c, err)
+	}
+}
+
+func TestParseErrorIs(t *testing.T) {
+	_, err := semver.New("1.01.1")
+	if !errors.Is(err, semver.ErrLeadingZero) {
+		t.Errorf("errors.Is(%v, ErrLeadingZero) => false, want true", err)
+	}
+	if errors.Is(err, semver.ErrInvalidCharacter) {
+		t.Errorf("errors.Is(%v, ErrInvalidCharacter) => true, want false", err)
+	}
+	ver := semver.Build(1, 2, 3)
+	if err := ver.SetPrerelease("alpha", "be.ta"); !errors.Is(err, semver.ErrInvalidCharacter) {
+		t.Errorf("SetPrerelease(%q, %q) => %v, want ErrInvalidCharacter", "alpha", "be.ta", err)
+	}
+}
+
+func TestParseErrorString(t *testing.T) {
+	_, err := semver.New("1.2.x")
+	expected := `invalid character "x" at offset 4 in "1.2.x"`
+	if result := err.Error(); result != expected {
+		t.Errorf("Error() => %q, want %q", result, expected)
+	}
+}
+
+func TestErrorKindString(t *testing.T) {
+	for kind, expected := range map[semver.ErrorKind]string{
+		semver.LeadingZero:   "leading zero",
+		semver.ErrorKind(42): "ErrorKind(42)",
+		-1:                   "ErrorKind(-1)",
+	} {
+		if result := kind.String(); result != expected {
+			t.Errorf("%d.String() => %q, want %q", int(kind), result, expected)
+		}
+	}
+	err := &semver.ParseError{Input: "1.2.3", Kind: 42}
+	if result := err.Unwrap(); result != nil {
+		t.Errorf("%q.Unwrap() => %v, want <nil>", err, result)
+	}
+}
@@ -0,0 +1,92 @@
+package semver
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Sugared returns r written with the shorthands ranges are usually written in, such as "~1.2.3 || 2.x",
+// where its comparators spell one. Unlike String, it keeps the comparators in the order they were
+// written, along with their build metadata, and it parses back to the same range given the same options.
+func (r *Range) Sugared() string {
+	n := r.root.(nodeRange)
+	if len(n.sets) == 0 {
+		return empty
+	}
+	var b bytes.Buffer
+	for i, set := range n.sets {
+		if i > 0 {
+			b.WriteString(" || ")
+		}
+		b.WriteString(set.sugared(n.options))
+	}
+	return b.String()
+}
+
+// sugared returns the comparators of n, writing each pair that spells a shorthand as that shorthand.
+func (n nodeSet) sugared(o Option) string {
+	var parts []string
+	for i := 0; i < len(n); i++ {
+		c := n[i]
+		if i+1 < len(n) {
+			if s := sugar(c, n[i+1], o); s != "" {
+				parts = append(parts, s)
+				i++
+				continue
+			}
+		}
+		switch {
+		case c.operator == string(operatorEQ):
+			parts = append(parts, c.arg.String())
+		case c.operator == operatorGE && c.arg.StrictEqual(lower(Version{}, o)):
+			parts = append(parts, "*")
+		default:
+			parts = append(parts, c.String())
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// sugar returns the shorthand the lower bound c and the upper bound next desugar from, or ""
+// if there is none. X-ranges are preferred over the caret and tilde ranges they equal.
+func sugar(c, next nodeComparison, o Option) string {
+	if c.operator != operatorGE {
+		return ""
+	}
+	if next.operator == operatorLE {
+		return c.arg.String() + " " + string(operatorHY) + " " + next.arg.String()
+	}
+	if next.operator != string(operatorLT) {
+		return ""
+	}
+
+	upper := func(v *Version, level Release) bool {
+		return next.arg.StrictEqual(below(v, level)[1].arg)
+	}
+	d := c.arg.decimals()
+	var release Version
+	release.setDecimals(d)
+	if c.arg.StrictEqual(lower(release, o)) {
+		switch {
+		case d[1] == "0" && d[2] == "0" && upper(&release, Major):
+			return d[0] + dot + "x"
+		case d[2] == "0" && upper(&release, Minor):
+			return d[0] + dot + d[1] + dot + "x"
+		}
+	}
+
+	level := Patch
+	switch {
+	case c.arg.major > 0:
+		level = Major
+	case c.arg.minor > 0:
+		level = Minor
+	}
+	switch {
+	case upper(c.arg, level):
+		return string(operatorCR) + c.arg.String()
+	case upper(c.arg, Minor):
+		return string(operatorTR) + c.arg.String()
+	}
+	return ""
+}
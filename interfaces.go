@@ -1,31 +1,159 @@
 package semver
 
 import (
+	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"strings"
 )
 
+// binaryVersion is the first byte of the binary encoding, allowing the format to change.
+// Versions with numbers beyond math.MaxUint64 use binaryLarge, holding them in decimal form.
+const (
+	binaryVersion = 1
+	binaryLarge   = 2
+)
+
+var errBinary = errors.New("invalid binary encoding of Version")
+
+// MarshalText implements the encoding.TextMarshaler interface, encoding the version as its semver string.
+func (v Version) MarshalText() ([]byte, error) {
+	return v.appendString(nil), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// Returns a *ParseError if the text is an invalid semver version.
+func (v *Version) UnmarshalText(text []byte) error {
+	ver, err := parse(text)
+	if err != nil {
+		return err
+	}
+	*v = ver
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface, encoding the version as a JSON string.
+func (v Version) MarshalJSON() ([]byte, error) {
+	text, err := v.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, accepting a JSON string.
+// Like the standard decoders, it leaves v unchanged for a JSON null.
 func (v *Version) UnmarshalJSON(b []byte) error {
-	input := strings.Trim(string(b), "\"")
-	ver, err := New(input)
-	*v = *ver
-	return err
+	if string(b) == "null" {
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(b, &text); err != nil {
+		return err
+	}
+	return v.UnmarshalText([]byte(text))
 }
 
-func (p *Version) MarshalJSON() ([]byte, error) {
-	return json.Marshal(p.String())
+// MarshalBinary implements the encoding.BinaryMarshaler interface. The encoding holds the
+// version numbers as varints, followed by the length prefixed prerelease and metadata.
+// Version numbers that don't fit in an uint64 are encoded as a length prefixed decimal string instead.
+func (v Version) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, 1+3*binary.MaxVarintLen64+len(v.large)+2+len(v.prerelease)+len(v.metadata))
+	if v.large != "" {
+		b = append(b, binaryLarge)
+		b = binary.AppendUvarint(b, uint64(len(v.large)))
+		b = append(b, v.large...)
+	} else {
+		b = append(b, binaryVersion)
+		b = binary.AppendUvarint(b, v.major)
+		b = binary.AppendUvarint(b, v.minor)
+		b = binary.AppendUvarint(b, v.patch)
+	}
+	b = binary.AppendUvarint(b, uint64(len(v.prerelease)))
+	b = append(b, v.prerelease...)
+	b = binary.AppendUvarint(b, uint64(len(v.metadata)))
+	b = append(b, v.metadata...)
+	return b, nil
 }
 
-type Versions []*Version
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (v *Version) UnmarshalBinary(data []byte) error {
+	if len(data) < 1 || (data[0] != binaryVersion && data[0] != binaryLarge) {
+		return errBinary
+	}
+	format := data[0]
+	data = data[1:]
+
+	var ver Version
+	var ok bool
+	if format == binaryLarge {
+		var large string
+		if large, data, ok = readString(data); !ok {
+			return errBinary
+		}
+		decimals := strings.Split(large, dot)
+		if len(decimals) != 3 {
+			return errBinary
+		}
+		for _, decimal := range decimals {
+			if checkNumber(large, 0, decimal) != nil {
+				return errBinary
+			}
+		}
+		if ver.setDecimals(decimals); ver.large == "" {
+			return errBinary // small numbers are always encoded as varints
+		}
+	} else {
+		for _, num := range []*uint64{&ver.major, &ver.minor, &ver.patch} {
+			n, size := binary.Uvarint(data)
+			if size <= 0 {
+				return errBinary
+			}
+			*num = n
+			data = data[size:]
+		}
+	}
+
+	var identifiers [2]string
+	for i := range identifiers {
+		if identifiers[i], data, ok = readString(data); !ok {
+			return errBinary
+		}
+	}
+	if len(data) > 0 {
+		return errBinary
+	}
+
+	if pre := identifiers[0]; pre != "" {
+		if end, err := scanIdentifiers(pre, 0, len(pre), true); err != nil || end < len(pre) {
+			return errBinary
+		}
+	}
+	if meta := identifiers[1]; meta != "" {
+		if _, err := scanIdentifiers(meta, 0, len(meta), false); err != nil {
+			return errBinary
+		}
+	}
+	ver.prerelease, ver.metadata = identifiers[0], identifiers[1]
+	*v = ver
+	return nil
+}
 
-func (v Versions) Len() int {
-	return len(v)
+// readString reads a length prefixed string from data, returning the data following it.
+func readString(data []byte) (string, []byte, bool) {
+	n, size := binary.Uvarint(data)
+	if size <= 0 || n > uint64(len(data)-size) {
+		return "", nil, false
+	}
+	return string(data[size : size+int(n)]), data[size+int(n):], true
 }
 
-func (v Versions) Swap(i, j int) {
-	v[i], v[j] = v[j], v[i]
+// GobEncode implements the gob.GobEncoder interface using the binary encoding.
+func (v Version) GobEncode() ([]byte, error) {
+	return v.MarshalBinary()
 }
 
-func (v Versions) Less(i, j int) bool {
-	return v[i].Compare(v[j]) < 0
+// GobDecode implements the gob.GobDecoder interface using the binary encoding.
+func (v *Version) GobDecode(data []byte) error {
+	return v.UnmarshalBinary(data)
 }
@@ -1,8 +1,11 @@
 package semver
 
 import (
+	"bytes"
+	"encoding/gob"
 	"encoding/json"
-	"sort"
+	"encoding/xml"
+	"flag"
 	"testing"
 )
 
@@ -39,48 +42,132 @@ func TestMarshal(t *testing.T) {
 	}
 }
 
-var unsortedVersions = []string{
-	"1.2.3-alpha.2+123.456",
-	"1.2.3-alpha.1",
-	"1.4.3+123.456",
-	"1.3.3-alpha.b-eta+123.b-uild",
-	"1.2.3+123.b-uild",
-	"1.2.3-alpha.b-eta",
-	"0.0.1-alpha.preview+123.456",
+func TestUnmarshalJSONError(t *testing.T) {
+	for _, input := range []string{`"1.2"`, `123`, `"1.2.3`} {
+		var ver Version
+		if err := json.Unmarshal([]byte(input), &ver); err == nil {
+			t.Errorf("UnmarshalJSON(%q) => <nil>, want Error", input)
+		}
+	}
+
+	value := struct{ V Version }{*Build(1, 2, 3)}
+	if err := json.Unmarshal([]byte(`{"V":null}`), &value); err != nil || value.V.String() != "1.2.3" {
+		t.Errorf("UnmarshalJSON(%q) => %q, %v, want %q, <nil>", "null", value.V, err, "1.2.3")
+	}
 }
 
-var sortedVersions = []string{
-	"0.0.1-alpha.preview+123.456",
-	"1.2.3-alpha.1",
-	"1.2.3-alpha.2+123.456",
-	"1.2.3-alpha.b-eta",
-	"1.2.3+123.b-uild",
-	"1.3.3-alpha.b-eta+123.b-uild",
-	"1.4.3+123.456",
+func TestMarshalText(t *testing.T) {
+	for _, version := range []string{"1.2.3", "1.2.3-alpha.1+123.456"} {
+		ver, _ := New(version)
+		text, err := ver.MarshalText()
+		if err != nil || string(text) != version {
+			t.Errorf("MarshalText(%q) => %q, %v, want %q, <nil>", ver, text, err, version)
+		}
+		var result Version
+		if err := result.UnmarshalText(text); err != nil || result != *ver {
+			t.Errorf("UnmarshalText(%q) => %q, %v, want %q, <nil>", text, result, err, ver)
+		}
+	}
+	var result Version
+	if err := result.UnmarshalText([]byte("1.2")); err == nil {
+		t.Errorf("UnmarshalText(%q) => <nil>, want Error", "1.2")
+	}
 }
 
-func TestSorter(t *testing.T) {
-	var unsortedlist Versions
-	for _, v := range unsortedVersions {
-		ver, _ := New(v)
-		unsortedlist = append(unsortedlist, ver)
+func TestTextEncoders(t *testing.T) {
+	v1, _ := New("1.2.3")
+	v2, _ := New("2.0.0-rc.1")
+
+	// Map keys
+	keys := map[Version]int{*v1: 1, *v2: 2}
+	b, err := json.Marshal(keys)
+	if err != nil {
+		t.Fatalf("json.Marshal(%v) => %v, want <nil>", keys, err)
+	}
+	expected := `{"1.2.3":1,"2.0.0-rc.1":2}`
+	if string(b) != expected {
+		t.Errorf("json.Marshal(%v) => %s, want %s", keys, b, expected)
+	}
+	decoded := map[Version]int{}
+	if err := json.Unmarshal(b, &decoded); err != nil || decoded[*v2] != 2 {
+		t.Errorf("json.Unmarshal(%s) => %v, %v, want %v", b, decoded, err, keys)
 	}
 
-	sort.Sort(unsortedlist)
+	// XML attributes
+	type release struct {
+		Version Version `xml:"version,attr"`
+	}
+	b, err = xml.Marshal(release{*v2})
+	expected = `<release version="2.0.0-rc.1"></release>`
+	if err != nil || string(b) != expected {
+		t.Errorf("xml.Marshal(%q) => %s, %v, want %s", v2, b, err, expected)
+	}
+	var r release
+	if err := xml.Unmarshal(b, &r); err != nil || r.Version != *v2 {
+		t.Errorf("xml.Unmarshal(%s) => %q, %v, want %q", b, r.Version, err, v2)
+	}
 
-	var sortedlist Versions
-	for _, v := range sortedVersions {
-		ver, _ := New(v)
-		sortedlist = append(sortedlist, ver)
+	// Flags
+	var flagged Version
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.TextVar(&flagged, "version", Version{}, "version")
+	if err := fs.Parse([]string{"-version", "1.2.3"}); err != nil || flagged != *v1 {
+		t.Errorf("flag.TextVar(%q) => %q, %v, want %q", "1.2.3", flagged, err, v1)
 	}
+}
 
-	for i, r := range unsortedlist {
-		result := r.String()
-		expected := sortedlist[i].String()
+func TestMarshalBinary(t *testing.T) {
+	for _, version := range []string{"0.0.0", "1.2.3", "18446744073709551615.2.3-alpha.1+123.456", "1.99999999999999999999.3-beta"} {
+		ver, _ := New(version)
+		data, err := ver.MarshalBinary()
+		if err != nil {
+			t.Errorf("MarshalBinary(%q) => %v, want <nil>", ver, err)
+		}
+		var result Version
+		if err := result.UnmarshalBinary(data); err != nil || result != *ver {
+			t.Errorf("UnmarshalBinary(MarshalBinary(%q)) => %q, %v, want %q, <nil>", ver, result, err, ver)
+		}
+	}
 
-		if result != expected {
-			t.Errorf("sort.Sort() => %q, want %v", result, expected)
+	ver, _ := New("1.2.3-alpha")
+	data, _ := ver.MarshalBinary()
+	invalid := [][]byte{
+		nil,
+		{0},
+		data[:len(data)-1],
+		append(data, 0),
+		{binaryVersion, 1, 2, 3, 1, '.', 0},
+		{binaryVersion, 1, 2, 3, 0, 1, '!'},
+		{binaryLarge, 5, '1', '.', '2', '.', '3', 0, 0},
+		{binaryLarge, 3, '1', '.', '2', 0, 0},
+		{binaryLarge, 6, '1', '.', '2', '.', '0', '3', 0, 0},
+	}
+	for _, data := range invalid {
+		var result Version
+		if err := result.UnmarshalBinary(data); err == nil {
+			t.Errorf("UnmarshalBinary(%v) => %q, want Error", data, result)
 		}
 	}
+}
+
+func TestGob(t *testing.T) {
+	type entry struct {
+		Name    string
+		Version Version
+		Pointer *Version
+	}
+	ver, _ := New("1.2.3-beta.2+exp.sha.5114f85")
+	in := entry{"semver", *ver, ver}
 
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatalf("gob.Encode(%v) => %v, want <nil>", in, err)
+	}
+	var out entry
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatalf("gob.Decode() => %v, want <nil>", err)
+	}
+	if out.Name != in.Name || out.Version != *ver || *out.Pointer != *ver {
+		t.Errorf("gob.Decode() => %v, want %v", out, in)
+	}
 }
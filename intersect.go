@@ -0,0 +1,128 @@
+package semver
+
+// Intersects reports whether some version matches both a and b.
+func Intersects(a, b *Range) bool {
+	return len(intersect(a.root.(nodeRange), b.root.(nodeRange)).sets) > 0
+}
+
+// Intersect returns the range of versions matching both a and b. Each of its comparator sets joins
+// a set of a with a set of b, leaving out the sets no version matches, so ranges that don't intersect
+// give a range without sets, which matches nothing and whose String is "<0.0.0-0".
+// The result matches a prerelease only if both a and b do, so it only has IncludePrerelease if both have it.
+func Intersect(a, b *Range) *Range {
+	return &Range{intersect(a.root.(nodeRange), b.root.(nodeRange))}
+}
+
+func intersect(a, b nodeRange) nodeRange {
+	result := nodeRange{options: a.options & b.options}
+	for _, sa := range a.sets {
+		for _, sb := range b.sets {
+			joined := append(append(nodeSet{}, sa...), sb...)
+			for _, set := range joined.exclude(excluded(sa, sb, a.options, b.options)) {
+				if set = set.tighten(); set.min(result.options) != nil {
+					result.sets = append(result.sets, set)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// excluded returns the versions whose prereleases a set joining sa and sb would allow, while sa or sb
+// on their own do not. A set allows the prereleases of every version one of its comparators has a
+// prerelease of, or all of them with IncludePrerelease.
+func excluded(sa, sb nodeSet, oa, ob Option) []Version {
+	if oa&IncludePrerelease != 0 && ob&IncludePrerelease != 0 {
+		return nil
+	}
+	var result []Version
+	for _, side := range []struct {
+		set, other nodeSet
+		o          Option
+	}{{sa, sb, ob}, {sb, sa, oa}} {
+		if side.o&IncludePrerelease != 0 {
+			continue
+		}
+		for _, c := range side.set {
+			if c.arg.isPrerelease() && !side.other.allows(c.arg) {
+				result = append(result, c.arg.release())
+			}
+		}
+	}
+	return result
+}
+
+// exclude returns sets matching the versions of n except the prereleases of the given versions,
+// splitting n around them where needed.
+func (n nodeSet) exclude(versions []Version) []nodeSet {
+	sets := []nodeSet{n}
+	for i := range versions {
+		v := &versions[i]
+		first := v.release()
+		first.prerelease = "0"
+
+		var split []nodeSet
+		for _, set := range sets {
+			prereleases := append(nodeSet{comparison(operatorGE, &first), comparison(string(operatorLT), v)}, set...)
+			if prereleases.min(IncludePrerelease) == nil {
+				split = append(split, set)
+				continue
+			}
+			for _, bound := range []nodeComparison{comparison(string(operatorLT), &first), comparison(operatorGE, v)} {
+				if part := append(nodeSet{bound}, set...); part.min(IncludePrerelease) != nil {
+					split = append(split, part)
+				}
+			}
+		}
+		sets = split
+	}
+	return sets
+}
+
+// tighten removes the comparators of n that other comparators make redundant, keeping its highest
+// lower bound and its lowest upper bound, or only its = comparator if that satisfies the rest.
+// The prereleases allowed by the removed comparators are out of bounds, so the set still matches the same versions.
+func (n nodeSet) tighten() nodeSet {
+	var lower, upper, equal *nodeComparison
+	for i, c := range n {
+		switch c.operator {
+		case string(operatorGT), operatorGE:
+			if lower == nil || tighter(c, *lower, 1) {
+				lower = &n[i]
+			}
+		case string(operatorLT), operatorLE:
+			if upper == nil || tighter(c, *upper, -1) {
+				upper = &n[i]
+			}
+		default:
+			if equal != nil && !c.arg.Equal(equal.arg) {
+				return n // no version equals both
+			}
+			equal = &n[i]
+		}
+	}
+
+	if equal != nil {
+		if n.Run(equal.arg) {
+			return nodeSet{*equal}
+		}
+		return n
+	}
+	var result nodeSet
+	for _, c := range []*nodeComparison{lower, upper} {
+		if c != nil {
+			result = append(result, *c)
+		}
+	}
+	return result
+}
+
+// tighter reports whether the bound c excludes more versions than other,
+// where direction is 1 for lower bounds and -1 for upper bounds.
+func tighter(c, other nodeComparison, direction int) bool {
+	if cmp := c.arg.Compare(other.arg); cmp != 0 {
+		return cmp == direction
+	}
+	// Of equal versions, > and < exclude the version itself.
+	return len(c.operator) < len(other.operator)
+}
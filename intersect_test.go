@@ -0,0 +1,127 @@
+package semver_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/hansrodtang/semver"
+)
+
+type intersection struct {
+	a, b     string
+	expected string
+}
+
+var intersections = []intersection{
+	{"^1.2.0", "<1.1.0 || >=2", "<0.0.0-0"},
+	{"^1.2.0", "<1.1 || >1", "<0.0.0-0"},
+	{"^1.2.0", "<=1.2 || >=1.5", ">=1.2.0 <1.3.0-0 || >=1.5.0 <2.0.0-0"},
+	{"^1.2.0", ">=1.5.0", ">=1.5.0 <2.0.0-0"},
+	{"1.x || 3.x", "^1.4.0 || ^3.1.0", ">=1.4.0 <2.0.0-0 || >=3.1.0 <4.0.0-0"},
+	{"~1.2.3", "1.2.3 - 1.2.7", ">=1.2.3 <=1.2.7"},
+	{"1.2.3", ">=1.0.0 <2.0.0", "=1.2.3"},
+	{"1.2.3", "1.2.4", "<0.0.0-0"},
+	{"*", "<1.0.0", ">=0.0.0 <1.0.0"},
+	{">1.2.3", "<=1.2.3", "<0.0.0-0"},
+	{">=1.2.3", "<=1.2.3", ">=1.2.3 <=1.2.3"},
+
+	// Prereleases only match if both ranges allow them.
+	{">=1.2.3-rc.1", ">=1.0.0", ">=1.2.3"},
+	{"^1.2.3-beta.2", "<1.2.3-beta.5", ">=1.2.3-beta.2 <1.2.3-beta.5"},
+	{"^1.2.3-beta.2", "~1.2.0", ">=1.2.3 <1.3.0-0"},
+	{"<=1.2.3-rc.1", ">=1.0.0 <2.0.0", ">=1.0.0 <1.2.3-0"},
+	{"=1.2.3-rc.1", ">=1.0.0", "<0.0.0-0"},
+	{"=1.2.3-rc.1", ">=1.2.3-beta", "=1.2.3-rc.1"},
+	{">1.2.3-rc.1 <1.2.3-rc.5", ">=1.2.3-rc.3", ">=1.2.3-rc.3 <1.2.3-rc.5"},
+
+	// Only prereleases of 1.2.1 match ">1.2.0 <=1.2.1-rc", which "*" and "1.2.x" leave out.
+	{">1.2.0 <=1.2.1-rc", ">1.2.0 <=1.2.1-rc", ">1.2.0 <=1.2.1-rc"},
+	{">1.2.0 <=1.2.1-rc", ">=1.2.1-alpha", ">=1.2.1-alpha <=1.2.1-rc"},
+	{">1.2.0 <=1.2.1-rc", "<1.2.1-beta", ">1.2.0 <1.2.1-beta"},
+	{">1.2.0 <=1.2.1-rc", "*", "<0.0.0-0"},
+	{">1.2.0 <=1.2.1-rc", "1.2.x", "<0.0.0-0"},
+}
+
+func TestIntersect(t *testing.T) {
+	for _, c := range intersections {
+		a, b := semver.MustParseRange(c.a), semver.MustParseRange(c.b)
+		if result := semver.Intersect(a, b).String(); result != c.expected {
+			t.Errorf("Intersect(%q, %q) => %q, want %q", a, b, result, c.expected)
+		}
+		if result := semver.Intersects(a, b); result != (c.expected != "<0.0.0-0") {
+			t.Errorf("Intersects(%q, %q) => %t, want %t", a, b, result, !result)
+		}
+	}
+}
+
+var intersectRanges = []string{
+	"*", "^1.2.0", "~1.2.3", "1.x || 3.x", "<1.1.0 || >=2.0.0", ">=1.2.3-rc.1", "^1.2.3-beta.2",
+	"<1.2.3-beta.5", "<=1.2.3-rc.1", "=1.2.3-rc.1", "1.2.3 - 2.0.0", ">0.0.0-0 <0.1.0", "^0.0.3-alpha",
+	">1.2.3 <1.2.3-rc || 1.2.3-rc.2", ">1.2.0 <=1.2.1-rc",
+}
+
+var intersectVersions = []string{
+	"0.0.0-0", "0.0.0", "0.0.3-alpha", "0.0.3-beta", "0.0.3", "0.0.4-0", "0.1.0-rc", "0.1.0", "1.0.0",
+	"1.1.0-rc", "1.1.0", "1.2.0", "1.2.1-0", "1.2.1-alpha", "1.2.1-rc", "1.2.1", "1.2.3-beta.1",
+	"1.2.3-beta.3", "1.2.3-beta.5", "1.2.3-rc.1", "1.2.3-rc.2", "1.2.3", "1.2.4-0", "1.2.9", "1.3.0-0",
+	"1.9.9", "2.0.0-rc", "2.0.0", "3.1.0-x", "3.5.0",
+}
+
+// matchRange is a range of intersectRanges parsed with options.
+type matchRange struct {
+	*semver.Range
+	options semver.Option
+}
+
+// matchRanges parses every range of intersectRanges with each of the options.
+func matchRanges(options ...semver.Option) []matchRange {
+	var ranges []matchRange
+	for _, input := range intersectRanges {
+		for _, o := range options {
+			ranges = append(ranges, matchRange{semver.MustParseRange(input, o), o})
+		}
+	}
+	return ranges
+}
+
+// matchVersions returns the versions of intersectVersions and extra, along with versions picked at
+// random around them to check the ranges against.
+func matchVersions(extra ...string) semver.Versions {
+	versions, _ := semver.ParseVersions(append(extra, intersectVersions...))
+	rnd := rand.New(rand.NewSource(1))
+	prereleases := [][]string{nil, nil, {"0"}, {"alpha"}, {"beta", "2"}, {"rc"}, {"rc", "1"}}
+	for i := 0; i < 50; i++ {
+		p := prereleases[rnd.Intn(len(prereleases))]
+		versions = append(versions, semver.Build(uint64(rnd.Intn(4)), uint64(rnd.Intn(4)), uint64(rnd.Intn(5)), p))
+	}
+	return versions
+}
+
+// eachMatchPair calls f with every pair of matchRanges without options and with IncludePrerelease.
+func eachMatchPair(f func(a, b matchRange)) {
+	ranges := matchRanges(0, semver.IncludePrerelease)
+	for _, a := range ranges {
+		for _, b := range ranges {
+			f(a, b)
+		}
+	}
+}
+
+// TestIntersectMatches checks that the intersection matches exactly the versions both ranges match.
+func TestIntersectMatches(t *testing.T) {
+	versions := matchVersions()
+	eachMatchPair(func(a, b matchRange) {
+		r := semver.Intersect(a.Range, b.Range)
+		matches := false
+		for _, v := range versions {
+			expected := a.Check(v) && b.Check(v)
+			if result := r.Check(v); result != expected {
+				t.Errorf("Intersect(%q, %q).Check(%q) with options %d, %d => %t, want %t", a, b, v, a.options, b.options, result, expected)
+			}
+			matches = matches || expected
+		}
+		if matches && !semver.Intersects(a.Range, b.Range) {
+			t.Errorf("Intersects(%q, %q) with options %d, %d => false, want true", a, b, a.options, b.options)
+		}
+	})
+}
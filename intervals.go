@@ -0,0 +1,244 @@
+package semver
+
+import (
+	"bytes"
+	"sort"
+)
+
+// Interval is a contiguous stretch of versions, from its lower bound up to but excluding its upper bound.
+// Every closed or open interval can be written this way, since each version has a lowest version above it.
+type Interval struct {
+	lower *Version
+	upper *Version // nil if the interval has no upper bound
+}
+
+// Lower returns the lowest version in the interval.
+func (i Interval) Lower() *Version {
+	return i.lower.Clone()
+}
+
+// Upper returns the version the interval ends before, or nil if it has no upper bound.
+func (i Interval) Upper() *Version {
+	if i.upper == nil {
+		return nil
+	}
+	return i.upper.Clone()
+}
+
+// Intervals is a set of versions, kept as sorted intervals that neither overlap nor touch, so each set has
+// a single representation. Unlike a Range, it treats prereleases like any other version, as IncludePrerelease
+// does, since the prereleases a Range leaves out otherwise don't form intervals.
+// The zero value is the empty set.
+type Intervals []Interval
+
+// Intervals returns the set of versions matching r, treating prereleases as ordinary versions, and
+// whether it holds exactly the versions r matches. Without IncludePrerelease that is false if the set
+// holds prereleases r leaves out, such as 1.5.0-rc for "^1.0.0", which Intervals can't leave out.
+func (r *Range) Intervals() (Intervals, bool) {
+	root := r.root.(nodeRange)
+	var result, prereleases Intervals
+	for _, set := range root.sets {
+		i := Interval{lower: minVersion}
+		for _, c := range set {
+			arg := *c.arg
+			arg.metadata = ""
+			lower, upper := &arg, &arg
+			switch c.operator {
+			case string(operatorGT):
+				lower, upper = arg.successor(), nil
+			case operatorGE:
+				upper = nil
+			case string(operatorLT):
+				lower = nil
+			case operatorLE:
+				lower, upper = nil, arg.successor()
+			default:
+				upper = arg.successor()
+			}
+			if lower != nil && lower.Compare(i.lower) > 0 {
+				i.lower = lower
+			}
+			if upper != nil && (i.upper == nil || upper.Compare(i.upper) < 0) {
+				i.upper = upper
+			}
+		}
+		if i.upper == nil || i.lower.Compare(i.upper) < 0 {
+			result = append(result, i)
+			prereleases = append(prereleases, Intervals{i}.Intersect(set.prereleases())...)
+		}
+	}
+	result = result.normalize()
+	return result, root.options&IncludePrerelease != 0 || !result.Difference(prereleases.normalize()).hasPrerelease()
+}
+
+// prereleases returns the prereleases a set without IncludePrerelease allows if it is satisfied,
+// those of each version with a prerelease comparator.
+func (n nodeSet) prereleases() Intervals {
+	var result Intervals
+	for _, c := range n {
+		if c.arg.isPrerelease() {
+			release := c.arg.release()
+			first := release
+			first.prerelease = "0"
+			result = append(result, Interval{&first, &release})
+		}
+	}
+	return result.normalize()
+}
+
+// hasPrerelease reports whether s holds a prerelease. An interval starting at a release holds one
+// unless it ends at the first prerelease following it.
+func (s Intervals) hasPrerelease() bool {
+	for _, i := range s {
+		if i.lower.isPrerelease() || i.upper == nil || i.lower.successor().Compare(i.upper) < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize sorts the intervals of s, merging those that overlap or touch.
+func (s Intervals) normalize() Intervals {
+	sorted := append(Intervals(nil), s...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].lower.Compare(sorted[j].lower) < 0
+	})
+
+	var result Intervals
+	for _, i := range sorted {
+		if n := len(result); n > 0 && (result[n-1].upper == nil || i.lower.Compare(result[n-1].upper) <= 0) {
+			if last := &result[n-1]; last.upper != nil && (i.upper == nil || i.upper.Compare(last.upper) > 0) {
+				last.upper = i.upper
+			}
+			continue
+		}
+		result = append(result, i)
+	}
+	return result
+}
+
+// Contains reports whether v is in s.
+func (s Intervals) Contains(v *Version) bool {
+	for _, i := range s {
+		if v.Compare(i.lower) >= 0 && (i.upper == nil || v.Compare(i.upper) < 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// Union returns the set of versions in s or other.
+func (s Intervals) Union(other Intervals) Intervals {
+	return append(append(Intervals(nil), s...), other...).normalize()
+}
+
+// Intersect returns the set of versions in both s and other.
+func (s Intervals) Intersect(other Intervals) Intervals {
+	var result Intervals
+	for i, j := 0, 0; i < len(s) && j < len(other); {
+		a, b := s[i], other[j]
+		lower, upper := a.lower, a.upper
+		if b.lower.Compare(lower) > 0 {
+			lower = b.lower
+		}
+		if upper == nil || (b.upper != nil && b.upper.Compare(upper) < 0) {
+			upper = b.upper
+		}
+		if upper == nil || lower.Compare(upper) < 0 {
+			result = append(result, Interval{lower, upper})
+		}
+		// Move past the interval that ends first, as it can't overlap any further intervals.
+		if a.upper != nil && (b.upper == nil || a.upper.Compare(b.upper) < 0) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return result
+}
+
+// Complement returns the set of versions not in s.
+func (s Intervals) Complement() Intervals {
+	var result Intervals
+	lower := minVersion
+	for _, i := range s {
+		if i.lower.Compare(lower) > 0 {
+			result = append(result, Interval{lower, i.lower})
+		}
+		if i.upper == nil {
+			return result
+		}
+		lower = i.upper
+	}
+	return append(result, Interval{lower: lower})
+}
+
+// Difference returns the set of versions in s but not in other.
+func (s Intervals) Difference(other Intervals) Intervals {
+	return s.Intersect(other.Complement())
+}
+
+// IsEmpty reports whether s holds no versions.
+func (s Intervals) IsEmpty() bool {
+	return len(s) == 0
+}
+
+// IsAny reports whether s holds every version.
+func (s Intervals) IsAny() bool {
+	return len(s) == 1 && s[0].lower.Equal(minVersion) && s[0].upper == nil
+}
+
+// Equal reports whether s and other hold the same versions.
+func (s Intervals) Equal(other Intervals) bool {
+	if len(s) != len(other) {
+		return false
+	}
+	for i := range s {
+		a, b := s[i], other[i]
+		if !a.lower.Equal(b.lower) || (a.upper == nil) != (b.upper == nil) || (a.upper != nil && !a.upper.Equal(b.upper)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Range returns a Range with IncludePrerelease matching the versions in s.
+// It matches the same versions as the Range s came from only if Range.Intervals reported it exact.
+func (s Intervals) Range() *Range {
+	root := nodeRange{options: IncludePrerelease}
+	for _, i := range s {
+		set := nodeSet{comparison(operatorGE, i.lower)}
+		if i.upper != nil {
+			set = append(set, comparison(string(operatorLT), i.upper))
+		}
+		root.sets = append(root.sets, set)
+	}
+	return &Range{root}
+}
+
+// String returns the intervals in range syntax, such as ">=1.2.3 <1.3.0-0 || =2.0.0".
+// A single version is written with =, every version as * and no version as <0.0.0-0.
+func (s Intervals) String() string {
+	if len(s) == 0 {
+		return empty
+	}
+	var b bytes.Buffer
+	for n, i := range s {
+		if n > 0 {
+			b.WriteString(" || ")
+		}
+		switch {
+		case i.upper != nil && i.upper.Equal(i.lower.successor()):
+			b.WriteString("=" + i.lower.String())
+		case i.lower.Equal(minVersion) && i.upper == nil:
+			b.WriteString("*")
+		case i.lower.Equal(minVersion):
+			b.WriteString("<" + i.upper.String())
+		case i.upper == nil:
+			b.WriteString(">=" + i.lower.String())
+		default:
+			b.WriteString(">=" + i.lower.String() + " <" + i.upper.String())
+		}
+	}
+	return b.String()
+}
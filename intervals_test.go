@@ -0,0 +1,140 @@
+package semver_test
+
+import (
+	"testing"
+
+	"github.com/hansrodtang/semver"
+)
+
+var intervalStrings = map[string]string{
+	"*":                          ">=0.0.0",
+	">=0.0.0-0":                  "*",
+	"":                           ">=0.0.0",
+	"1.2.3":                      "=1.2.3",
+	"1.2.3+build":                "=1.2.3",
+	"<=1.2.3":                    "<1.2.4-0",
+	">1.2.3-rc":                  ">=1.2.3-rc.0",
+	"~1.2.3":                     ">=1.2.3 <1.3.0-0",
+	"1.x || 1.5.x || >=3.0.0":    ">=1.0.0 <2.0.0-0 || >=3.0.0",
+	"1.2.3 || 1.2.4-0 || 1.2.4":  ">=1.2.3 <1.2.4-0.0 || =1.2.4",
+	"<1.0.0 || >=1.0.0":          "*",
+	">=2.0.0 <1.0.0":             "<0.0.0-0",
+	"1.2.3 - 1.4.0 || 1.3.x":     ">=1.2.3 <1.4.1-0",
+	"<=1.2.3 >=1.2.3":            "=1.2.3",
+	">=0.0.0-0 <1.0.0 || >2.0.0": "<1.0.0 || >=2.0.1-0",
+}
+
+// exactIntervals are the inputs of intervalStrings whose intervals hold no prerelease the range leaves out.
+var exactIntervals = map[string]bool{
+	"1.2.3": true, "1.2.3+build": true, "1.2.3 || 1.2.4-0 || 1.2.4": true, ">=2.0.0 <1.0.0": true, "<=1.2.3 >=1.2.3": true,
+}
+
+// intervals returns the intervals of r, whether they are exact or not.
+func intervals(r *semver.Range) semver.Intervals {
+	i, _ := r.Intervals()
+	return i
+}
+
+func TestIntervals(t *testing.T) {
+	for input, expected := range intervalStrings {
+		r := semver.MustParseRange(input)
+		if result, exact := r.Intervals(); result.String() != expected || exact != exactIntervals[input] {
+			t.Errorf("%q.Intervals() => %q, %t, want %q, %t", r, result, exact, expected, exactIntervals[input])
+		}
+		r = semver.MustParseRange(input, semver.IncludePrerelease)
+		if _, exact := r.Intervals(); !exact {
+			t.Errorf("%q.Intervals() with IncludePrerelease => %t, want true", r, exact)
+		}
+	}
+}
+
+type algebra struct {
+	a, b                                string
+	union, intersect, difference, compl string
+}
+
+var algebras = []algebra{
+	{"^1.2.0", "<1.1.0 || >=2.0.0", "<1.1.0 || >=1.2.0 <2.0.0-0 || >=2.0.0", "<0.0.0-0", ">=1.2.0 <2.0.0-0", "<1.2.0 || >=2.0.0-0"},
+	{"1.x", "1.5.x", ">=1.0.0 <2.0.0-0", ">=1.5.0 <1.6.0-0", ">=1.0.0 <1.5.0 || >=1.6.0-0 <2.0.0-0", "<1.0.0 || >=2.0.0-0"},
+	{">=0.0.0-0", "1.2.3", "*", "=1.2.3", "<1.2.3 || >=1.2.4-0", "<0.0.0-0"},
+	{"*", "1.2.3", ">=0.0.0", "=1.2.3", ">=0.0.0 <1.2.3 || >=1.2.4-0", "<0.0.0"},
+	{"<0.0.0-0", "1.2.3", "=1.2.3", "<0.0.0-0", "<0.0.0-0", "*"},
+	{"<1.0.0", ">=1.0.0", "*", "<0.0.0-0", "<1.0.0", ">=1.0.0"},
+}
+
+func TestIntervalsAlgebra(t *testing.T) {
+	for _, c := range algebras {
+		a, b := intervals(semver.MustParseRange(c.a)), intervals(semver.MustParseRange(c.b))
+		results := map[string][2]string{
+			"Union":      {a.Union(b).String(), c.union},
+			"Intersect":  {a.Intersect(b).String(), c.intersect},
+			"Difference": {a.Difference(b).String(), c.difference},
+			"Complement": {a.Complement().String(), c.compl},
+		}
+		for op, r := range results {
+			if r[0] != r[1] {
+				t.Errorf("%q.%v(%q) => %q, want %q", a, op, b, r[0], r[1])
+			}
+		}
+	}
+}
+
+// TestIntervalsMatches checks the set operations against matching single versions.
+func TestIntervalsMatches(t *testing.T) {
+	versions := matchVersions("0.0.0-0.0", "1.2.3-rc.1.0", "1.2.4-0.0", "2.0.1-0")
+	ranges := matchRanges(semver.IncludePrerelease)
+	for _, ra := range ranges {
+		a := intervals(ra.Range)
+		if !intervals(a.Range()).Equal(a) || !a.Complement().Complement().Equal(a) {
+			t.Errorf("%q.Intervals() => %q doesn't roundtrip", ra, a)
+		}
+		if !a.Union(a.Complement()).IsAny() || !a.Intersect(a.Complement()).IsEmpty() {
+			t.Errorf("%q.Intervals() => %q isn't complemented by %q", ra, a, a.Complement())
+		}
+		for _, rb := range ranges {
+			b := intervals(rb.Range)
+			if !a.Union(b).Equal(b.Union(a)) || !a.Intersect(b).Equal(b.Intersect(a)) {
+				t.Errorf("Union or Intersect of %q and %q isn't symmetric", a, b)
+			}
+			if equal := a.Difference(b).IsEmpty() && b.Difference(a).IsEmpty(); a.Equal(b) != equal {
+				t.Errorf("%q.Equal(%q) => %t, want %t", a, b, !equal, equal)
+			}
+			for _, v := range versions {
+				ina, inb := ra.Check(v), rb.Check(v)
+				if a.Contains(v) != ina {
+					t.Errorf("%q.Contains(%q) => %t, want %t", a, v, !ina, ina)
+				}
+				checks := map[string][2]bool{
+					"Union":      {a.Union(b).Contains(v), ina || inb},
+					"Intersect":  {a.Intersect(b).Contains(v), ina && inb},
+					"Difference": {a.Difference(b).Contains(v), ina && !inb},
+					"Complement": {a.Complement().Contains(v), !ina},
+					"Range":      {a.Range().Check(v), ina},
+				}
+				for op, c := range checks {
+					if c[0] != c[1] {
+						t.Errorf("%q.%v(%q).Contains(%q) => %t, want %t", a, op, b, v, c[0], c[1])
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestIntervalsExact checks that the intervals of a range without IncludePrerelease hold every version it
+// matches, and only those if they are reported exact.
+func TestIntervalsExact(t *testing.T) {
+	versions := matchVersions("0.0.0-0.0", "1.2.3-rc.1.0", "1.2.4-0.0", "2.0.1-0")
+	for _, r := range matchRanges(0) {
+		i, exact := r.Intervals()
+		for _, v := range versions {
+			if matches := r.Check(v); i.Contains(v) != matches && (matches || exact) {
+				t.Errorf("%q.Intervals() => %q, %t, but %q.Check(%q) => %t", r, i, exact, r, v, matches)
+			}
+		}
+	}
+	r := semver.MustParseRange("^1.0.0")
+	if i, exact := r.Intervals(); exact || !i.Range().Check(semver.Build(1, 5, 0, []string{"rc"})) {
+		t.Errorf("%q.Intervals() => %q, %t, want false", r, i, exact)
+	}
+}
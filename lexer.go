@@ -1,4 +1,4 @@
-package parser
+package semver
 
 import (
 	"fmt"
@@ -34,16 +34,11 @@ const (
 
 	eof = -1
 
-	numbers string = "0123456789"
+	digits  string = "0123456789"
 	letters        = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ-"
 
-	dot        = "."
-	hyphen     = "-"
-	plus       = "+"
-	delimiters = dot + hyphen + plus
-
 	allchars  = alphanum + delimiters
-	alphanum  = letters + numbers
+	alphanum  = letters + digits
 	wildcards = "Xx*"
 )
 
@@ -63,6 +58,7 @@ type itemType int
 type item struct {
 	typ itemType
 	val string
+	pos int // byte offset of val in the input
 }
 
 func (i item) String() string {
@@ -70,35 +66,40 @@ func (i item) String() string {
 }
 
 type lexer struct {
-	input string    // the string being scanned.
-	start int       // start position of this item.
-	pos   int       // current position in the input.
-	width int       // width of last rune read from input.
-	items chan item // channel of scanned items.
+	input string  // the string being scanned.
+	start int     // start position of this item.
+	pos   int     // current position in the input.
+	width int     // width of last rune read from input.
+	state stateFn // next state to run, nil once the scan has stopped.
+	items []item  // scanned items not yet returned by nextItem.
+	buf   [2]item // backing array for items, no state emits more than two.
 }
 
 func lex(input string) *lexer {
-	l := &lexer{
+	return &lexer{
 		input: input,
-		items: make(chan item),
-	}
-	go l.run() // Concurrently run state machine.
-	return l
-}
-
-func (l *lexer) run() {
-	for state := lexMain; state != nil; {
-		state = state(l)
+		state: lexMain,
 	}
 }
 
+// nextItem runs the state machine until it has emitted an item and returns it.
+// Once the scan has stopped it keeps returning itemEOF.
 func (l *lexer) nextItem() item {
-	return <-l.items
+	for len(l.items) == 0 {
+		if l.state == nil {
+			return item{itemEOF, "", len(l.input)}
+		}
+		l.items = l.buf[:0]
+		l.state = l.state(l)
+	}
+	i := l.items[0]
+	l.items = l.items[1:]
+	return i
 }
 
 // emit passes an item back to the client.
 func (l *lexer) emit(t itemType) {
-	l.items <- item{t, l.input[l.start:l.pos]}
+	l.items = append(l.items, item{t, l.input[l.start:l.pos], l.start})
 	l.start = l.pos
 }
 
@@ -157,11 +158,12 @@ func (l *lexer) acceptRun(valid string) {
 	l.backup()
 }
 
-func (l *lexer) errorf(format string, args ...interface{}) stateFn {
-	l.items <- item{
-		itemError,
-		fmt.Sprintf(format, args...),
-	}
+// unexpected emits an error item holding the rune at the current
+// position, which is empty at the end of the input, and stops the scan.
+func (l *lexer) unexpected() stateFn {
+	l.ignore()
+	l.next()
+	l.emit(itemError)
 	return nil
 }
 
@@ -191,30 +193,30 @@ func lexMain(l *lexer) stateFn {
 	case l.check(wildcards):
 		return lexAdvancedVersion
 	default:
-		return l.errorf("invalid character:%v: %q", l.pos, string(r))
+		return l.unexpected()
 	}
 }
 
 func lexVersion(l *lexer) stateFn {
 
-	l.acceptRun(numbers)
+	l.acceptRun(digits)
 	if l.accept(dot) {
-		if l.accept(numbers) {
-			l.acceptRun(numbers)
+		if l.accept(digits) {
+			l.acceptRun(digits)
 
 			if l.accept(dot) {
-				if l.accept(numbers) {
-					l.acceptRun(numbers)
+				if l.accept(digits) {
+					l.acceptRun(digits)
 
 					if l.accept("+-") {
 						if !l.accept(allchars) {
-							return l.errorf("invalid character:%v: %q", l.pos, string(l.next()))
+							return l.unexpected()
 						}
 						l.acceptRun(allchars)
 					}
 
 					if !isEnd(l.peek()) {
-						return l.errorf("invalid character:%v: %q", l.pos, string(l.next()))
+						return l.unexpected()
 					}
 
 					l.emit(itemVersion)
@@ -230,8 +232,8 @@ func lexVersion(l *lexer) stateFn {
 func lexOperator(l *lexer) stateFn {
 	l.accept(string(operatorGT) + string(operatorLT))
 	l.accept(string(operatorEQ))
-	if !l.check(numbers) {
-		return l.errorf("invalid character:%v: %q", l.pos, string(l.next()))
+	if !l.check(digits + wildcards) {
+		return l.unexpected()
 	}
 	l.emit(itemOperator)
 	return lexMain
@@ -261,11 +263,11 @@ func lexRange(l *lexer) stateFn {
 			l.ignore()
 		}
 		if isEnd(l.peek()) {
-			return l.errorf("invalid character:%v: %q", l.pos, string(l.next()))
+			return l.unexpected()
 		}
 		return lexMain
 	}
-	return l.errorf("invalid character:%v: %q", l.pos, string(l.next()))
+	return l.unexpected()
 
 }
 
@@ -276,15 +278,15 @@ func lexAdvancedRange(l *lexer) stateFn {
 			l.next()
 			l.ignore()
 		} else {
-			return l.errorf("invalid character:%v: %q", l.pos, string(l.next()))
+			return l.unexpected()
 		}
 		return lexMain
 	}
 	if l.accept(string(operatorCR) + string(operatorTR)) {
 		l.emit(itemAdvanced)
 
-		if !l.check(numbers) {
-			return l.errorf("invalid character:%v: %q", l.pos, string(l.next()))
+		if !l.check(digits) {
+			return l.unexpected()
 		}
 	}
 
@@ -295,15 +297,15 @@ func lexAdvancedVersion(l *lexer) stateFn {
 	// Syntax check
 	for i := 0; i <= 2; i++ {
 		if !l.accept(wildcards) {
-			if !l.accept(numbers) {
-				return l.errorf("invalid character:%v: %q", l.pos, string(l.next()))
+			if !l.accept(digits) {
+				return l.unexpected()
 			}
-			l.acceptRun(numbers)
+			l.acceptRun(digits)
 		}
 
 		if !l.accept(dot) {
 			if !isEnd(l.peek()) {
-				return l.errorf("invalid character:%v: %q", l.pos, string(l.next()))
+				return l.unexpected()
 			}
 			l.rewind()
 			break
@@ -313,7 +315,7 @@ func lexAdvancedVersion(l *lexer) stateFn {
 	// Generate item
 	for i := 0; i <= 2; i++ {
 		if !l.accept(wildcards) {
-			l.acceptRun(numbers)
+			l.acceptRun(digits)
 			l.accept(dot)
 		} else {
 			l.emit(itemXRange)
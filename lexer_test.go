@@ -1,4 +1,4 @@
-package parser
+package semver
 
 import (
 	"fmt"
@@ -7,7 +7,16 @@ import (
 	"github.com/fatih/color"
 )
 
-type results []item
+type result struct {
+	typ itemType
+	val string
+}
+
+func (r result) String() string {
+	return item{typ: r.typ, val: r.val}.String()
+}
+
+type results []result
 
 type lexerTestables struct {
 	expected bool
@@ -41,6 +50,12 @@ var constraints = []*lexerTestables{
 	{true, ">=1.2.3",
 		results{{itemOperator, ">="}, {itemVersion, "1.2.3"}},
 	},
+	{true, ">2",
+		results{{itemOperator, ">"}, {itemXRange, "2"}},
+	},
+	{true, "<=*",
+		results{{itemOperator, "<="}, {itemXRange, "*"}},
+	},
 	// Sets
 	{true, "5.3.5 4.3.5",
 		results{{itemVersion, "5.3.5"}, {itemSet, " "}, {itemVersion, "4.3.5"}},
@@ -168,9 +183,9 @@ func init() {
 	// Appends appropriate end token based on expected result.
 	for _, c := range constraints {
 		if c.expected {
-			c.result = append(c.result, item{itemEOF, ""})
+			c.result = append(c.result, result{itemEOF, ""})
 		} else {
-			c.result = append(c.result, item{itemError, ""})
+			c.result = append(c.result, result{itemError, ""})
 		}
 	}
 }
@@ -198,6 +213,9 @@ func TestLexer(t *testing.T) {
 			} else {
 				t.Errorf("lex(%v) => %v, want <nil>\n", cyan(c.value), yellow(i))
 			}
+			if c.value[i.pos:i.pos+len(i.val)] != i.val {
+				t.Errorf("lex(%v) => %v at offset %d, want offset of %q \n", cyan(c.value), yellow(i), i.pos, i.val)
+			}
 			x++
 			if i.typ == itemEOF || i.typ == itemError {
 				break
@@ -211,7 +229,7 @@ func TestLexer(t *testing.T) {
 
 func TestStringer(t *testing.T) {
 	expected := "itemError(success)"
-	result := fmt.Sprint(item{itemError, "success"})
+	result := fmt.Sprint(item{typ: itemError, val: "success"})
 	if result != expected {
 		t.Errorf("String() => %q, want %q \n", result, expected)
 	}
@@ -0,0 +1,156 @@
+package semver
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Fix records a correction ParseLoose had to make to turn its input into a valid version.
+type Fix int
+
+const (
+	FixWhitespace       Fix = 1 << iota // surrounding whitespace removed, as in " 1.2.3 "
+	FixPrefix                           // leading "v" or "=" removed, as in "v1.2.3"
+	FixMissingComponent                 // missing minor or patch version set to 0, as in "1.2"
+	FixExtraComponent                   // components after the patch version dropped, as in "1.2.3.4"
+	FixLeadingZero                      // leading zeroes removed from a version number, as in "1.02.3"
+	FixHyphen                           // hyphen inserted before the prerelease, as in "1.2.3beta"
+)
+
+var fixes = []string{
+	"whitespace",
+	"prefix",
+	"missing component",
+	"extra component",
+	"leading zero",
+	"hyphen",
+}
+
+// String returns the names of the corrections contained in f, separated by commas.
+func (f Fix) String() string {
+	var names []string
+	for i, name := range fixes {
+		if f&(1<<uint(i)) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// ParseLoose accepts a version string as commonly found in tags and package indexes, such as
+// "v1.2.3", "=1.2", " 1.2.3 ", "1.2.3.4" or "1.2.3beta", and returns a Version struct.
+// The corrections it had to make are reported as a combination of Fix values, which is 0 for valid semver.
+// Returns a *ParseError if no version could be recovered from the supplied string.
+func ParseLoose(version string) (*Version, Fix, error) {
+	var fix Fix
+	start, end := 0, len(version)
+
+	for start < end && unicode.IsSpace(rune(version[start])) {
+		start++
+	}
+	for end > start && unicode.IsSpace(rune(version[end-1])) {
+		end--
+	}
+	if start > 0 || end < len(version) {
+		fix |= FixWhitespace
+	}
+
+	for start < end && strings.IndexByte("=vV", version[start]) >= 0 {
+		start++
+		fix |= FixPrefix
+	}
+
+	result := new(Version)
+	pos, f, err := result.scanNumbers(version, start, end)
+	if err != nil {
+		return nil, 0, err
+	}
+	fix |= f
+
+	if i := strings.IndexByte(version[pos:end], '+'); i >= 0 {
+		if _, err := scanIdentifiers(version, pos+i+1, end, false); err != nil {
+			return nil, 0, err
+		}
+		result.metadata = version[pos+i+1 : end]
+		end = pos + i
+	}
+
+	if pos < end {
+		if version[pos] == '-' {
+			pos++
+		} else {
+			fix |= FixHyphen
+		}
+		if _, err := scanIdentifiers(version, pos, end, true); err != nil {
+			return nil, 0, err
+		}
+		result.prerelease = version[pos:end]
+	}
+
+	return result, fix, nil
+}
+
+// Coerce extracts the first version number found in the supplied string, such as "2.4" from "release-2.4-final".
+// Missing minor and patch versions are set to 0, while prerelease and metadata are dropped.
+// Returns a *ParseError if the supplied string does not contain any number.
+func Coerce(version string) (*Version, error) {
+	start := strings.IndexFunc(version, numbers)
+	if start < 0 {
+		return nil, &ParseError{version, len(version), "", MissingComponent}
+	}
+	result := new(Version)
+	if _, _, err := result.scanNumbers(version, start, len(version)); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// scanNumbers reads up to three dot separated version numbers from input[start:end] into v,
+// skipping any further components. Returns the position following the last component read.
+func (v *Version) scanNumbers(input string, start, end int) (int, Fix, error) {
+	var fix Fix
+	versionNumbers := []string{"0", "0", "0"}
+	n := 0
+	pos := start
+
+	for {
+		i := pos
+		for i < end && numbers(rune(input[i])) {
+			i++
+		}
+		if i == pos {
+			if pos == end {
+				return 0, 0, &ParseError{input, pos, "", MissingComponent}
+			}
+			return 0, 0, &ParseError{input, pos, input[pos : pos+1], InvalidCharacter}
+		}
+
+		number := input[pos:i]
+		if hasLeadingZero(number) {
+			fix |= FixLeadingZero
+			number = strings.TrimLeft(number, "0")
+			if number == "" {
+				number = "0"
+			}
+		}
+		if n < 3 {
+			versionNumbers[n] = number
+		} else {
+			fix |= FixExtraComponent
+		}
+		n++
+		pos = i
+
+		if pos+1 < end && input[pos] == '.' && numbers(rune(input[pos+1])) {
+			pos++
+			continue
+		}
+		break
+	}
+	if n < 3 {
+		fix |= FixMissingComponent
+	}
+
+	v.setDecimals(versionNumbers)
+	return pos, fix, nil
+}
@@ -0,0 +1,99 @@
+package semver_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hansrodtang/semver"
+)
+
+type looseTest struct {
+	input    string
+	expected string
+	fix      semver.Fix
+}
+
+var looseVersions = []looseTest{
+	{"1.2.3", "1.2.3", 0},
+	{"1.2.3-beta.1+build.5", "1.2.3-beta.1+build.5", 0},
+	{"v1.2.3", "1.2.3", semver.FixPrefix},
+	{"=1.2.3", "1.2.3", semver.FixPrefix},
+	{"=v1.2.3", "1.2.3", semver.FixPrefix},
+	{" 1.2.3 ", "1.2.3", semver.FixWhitespace},
+	{"1.2", "1.2.0", semver.FixMissingComponent},
+	{"1", "1.0.0", semver.FixMissingComponent},
+	{"1.2.3.4", "1.2.3", semver.FixExtraComponent},
+	{"1.02.3", "1.2.3", semver.FixLeadingZero},
+	{"1.00.3", "1.0.3", semver.FixLeadingZero},
+	{"1.2.3beta", "1.2.3-beta", semver.FixHyphen},
+	{"1.2beta.2", "1.2.0-beta.2", semver.FixMissingComponent | semver.FixHyphen},
+	{"1.2.3.4-rc.1+sha.5", "1.2.3-rc.1+sha.5", semver.FixExtraComponent},
+	{" v1.2 ", "1.2.0", semver.FixWhitespace | semver.FixPrefix | semver.FixMissingComponent},
+}
+
+var badLooseVersions = []string{
+	"",
+	"v",
+	"latest",
+	"1.2.3-",
+	"1.2.3-beta..1",
+	"1.2.3-01",
+	"1.2.3 4",
+}
+
+var coercions = map[string]string{
+	"1.2.3":             "1.2.3",
+	"v2":                "2.0.0",
+	"v1.2.3-beta+build": "1.2.3",
+	"1.2.3.4":           "1.2.3",
+	"release-2.4-final": "2.4.0",
+	"node-v10.15.03":    "10.15.3",
+	"3.x":               "3.0.0",
+}
+
+func TestParseLoose(t *testing.T) {
+	for _, c := range looseVersions {
+		ver, fix, err := semver.ParseLoose(c.input)
+		if err != nil {
+			t.Errorf("ParseLoose(%q) => %v, want <nil>", c.input, err)
+			continue
+		}
+		if result := ver.String(); result != c.expected || fix != c.fix {
+			t.Errorf("ParseLoose(%q) => %q, %v, want %q, %v", c.input, result, fix, c.expected, c.fix)
+		}
+	}
+}
+
+func TestParseLooseError(t *testing.T) {
+	for _, input := range badLooseVersions {
+		ver, _, err := semver.ParseLoose(input)
+		var perr *semver.ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("ParseLoose(%q) => %v, %v, want *ParseError", input, ver, err)
+		}
+	}
+}
+
+func TestCoerce(t *testing.T) {
+	for input, expected := range coercions {
+		ver, err := semver.Coerce(input)
+		if err != nil {
+			t.Errorf("Coerce(%q) => %v, want <nil>", input, err)
+			continue
+		}
+		if result := ver.String(); result != expected {
+			t.Errorf("Coerce(%q) => %q, want %q", input, result, expected)
+		}
+	}
+	if ver, err := semver.Coerce("latest"); err == nil {
+		t.Errorf("Coerce(%q) => %v, want Error", "latest", ver)
+	}
+}
+
+func TestFixString(t *testing.T) {
+	fix := semver.FixPrefix | semver.FixMissingComponent
+	expected := "prefix, missing component"
+	if result := fix.String(); result != expected {
+		t.Errorf("String() => %q, want %q", result, expected)
+	}
+}
@@ -0,0 +1,223 @@
+package semver
+
+import (
+	"bytes"
+	"sort"
+)
+
+type nodeType int
+
+const (
+	errorNode nodeType = iota
+	rangeNode
+	comparisonNode
+	setNode
+)
+
+type node interface {
+	Run(*Version) bool
+	String() string
+	Type() nodeType
+}
+
+type nodeContainer node
+
+type nodeError struct {
+	err *ParseError
+}
+
+func (n nodeError) Run(main *Version) bool {
+	return false
+}
+
+func (n nodeError) String() string {
+	return n.err.Error()
+}
+
+func (n nodeError) Type() nodeType {
+	return errorNode
+}
+
+type nodeComparison struct {
+	operator string // such as ">="
+	action   comparatorFunc
+	arg      *Version
+}
+
+// comparison returns the comparison of versions to v with the given operator.
+func comparison(operator string, v *Version) nodeComparison {
+	return nodeComparison{operator, comparators[operator], v}
+}
+
+func (n nodeComparison) Run(main *Version) bool {
+	return n.action(main, n.arg)
+}
+
+func (n nodeComparison) String() string {
+	return n.operator + n.arg.String()
+}
+
+// rank orders comparators as nodeSet.String writes them: lower bounds, then equalities, then upper bounds.
+func (n nodeComparison) rank() int {
+	switch n.operator {
+	case operatorGE, string(operatorGT):
+		return 0
+	case string(operatorEQ):
+		return 1
+	}
+	return 2
+}
+
+func (n nodeComparison) Type() nodeType {
+	return comparisonNode
+}
+
+type nodeRange struct {
+	sets    []nodeSet
+	options Option
+}
+
+// empty is how a range without sets, which matches no version, is written. An empty range string
+// stands for "*", so it is written as the comparator npm uses for such a range instead.
+const empty = "<0.0.0-0"
+
+func (n nodeRange) Run(main *Version) bool {
+	for _, c := range n.sets {
+		if c.matches(main, n.options) {
+			return true
+		}
+	}
+	return false
+}
+
+// min returns the lowest version matching the range, or nil if it matches none.
+func (n nodeRange) min() *Version {
+	var min *Version
+	for _, c := range n.sets {
+		if v := c.min(n.options); v != nil && (min == nil || v.Compare(min) < 0) {
+			min = v
+		}
+	}
+	return min
+}
+
+func (n nodeRange) String() string {
+	if len(n.sets) == 0 {
+		return empty
+	}
+	var b bytes.Buffer
+	for i, v := range n.sets {
+		b.WriteString(v.String())
+		if len(n.sets)-1 > i {
+			b.WriteString(" || ")
+		}
+	}
+
+	return b.String()
+}
+
+func (n nodeRange) Type() nodeType {
+	return rangeNode
+}
+
+type nodeSet []nodeComparison
+
+func (n nodeSet) Run(main *Version) bool {
+	for _, c := range n {
+		if c.Run(main) != true {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the comparators of the set in a normal form: lower bounds first, then equalities,
+// then upper bounds, each in order of their versions, which are written without build metadata
+// as it doesn't affect which versions match.
+func (n nodeSet) String() string {
+	sorted := append(nodeSet(nil), n...)
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.rank() != b.rank() {
+			return a.rank() < b.rank()
+		}
+		if c := a.arg.Compare(b.arg); c != 0 {
+			return c < 0
+		}
+		// Of two bounds on the same version, the one that falls first.
+		return a.operator != b.operator && (a.operator == operatorGE || a.operator == string(operatorLT))
+	})
+
+	var b bytes.Buffer
+	for i, c := range sorted {
+		if i > 0 {
+			b.WriteString(" ")
+		}
+		arg := *c.arg
+		arg.metadata = ""
+		b.WriteString(c.operator + arg.String())
+	}
+	return b.String()
+}
+
+func (n nodeSet) Type() nodeType {
+	return setNode
+}
+
+// matches reports whether main satisfies the set and is allowed to match it by the options.
+func (n nodeSet) matches(main *Version, o Option) bool {
+	return n.Run(main) && (o&IncludePrerelease != 0 || n.allows(main))
+}
+
+// min returns the lowest version matching the set, or nil if it matches none.
+// Every version from its highest lower bound up satisfies its lower bounds, and whether a prerelease
+// may match depends only on its major.minor.patch, so that is the bound if it matches, or else the
+// release of the bound, provided it passes the upper bounds.
+func (n nodeSet) min(o Option) *Version {
+	bound := *minVersion
+	for _, c := range n {
+		var v Version
+		switch c.operator {
+		case string(operatorGT):
+			v = *c.arg.successor()
+		case operatorGE, string(operatorEQ):
+			v = *c.arg
+			v.metadata = ""
+		default:
+			continue
+		}
+		if v.Compare(&bound) > 0 {
+			bound = v
+		}
+	}
+
+	release := bound.release()
+	for _, v := range []*Version{&bound, &release} {
+		if n.matches(v, o) {
+			return v
+		}
+	}
+	return nil
+}
+
+// allows reports whether a version that satisfies the set may match it.
+// Prerelease versions only match sets with a prerelease comparator on the same major.minor.patch.
+func (n nodeSet) allows(main *Version) bool {
+	if !main.isPrerelease() {
+		return true
+	}
+	for _, c := range n {
+		if c.arg.isPrerelease() && c.arg.major == main.major && c.arg.minor == main.minor && c.arg.patch == main.patch && c.arg.large == main.large {
+			return true
+		}
+	}
+	return false
+}
+
+var comparators = map[string]comparatorFunc{
+	string(operatorGT): gt,
+	string(operatorGE): gte,
+	string(operatorLT): lt,
+	string(operatorLE): lte,
+	string(operatorEQ): eq,
+}
@@ -0,0 +1,168 @@
+package semver
+
+import "strings"
+
+type comparatorFunc func(*Version, *Version) bool
+type satisfactionMap map[*Version]comparatorFunc
+
+func gt(main, other *Version) bool {
+	return main.Compare(other) > 0
+}
+
+func gte(main, other *Version) bool {
+	return main.Compare(other) >= 0
+}
+
+func lt(main, other *Version) bool {
+	return main.Compare(other) < 0
+}
+
+func lte(main, other *Version) bool {
+	return main.Compare(other) <= 0
+}
+
+func eq(main, other *Version) bool {
+	return main.Compare(other) == 0
+}
+
+func hy2op(v1, v2 *Version) node {
+	return nodeSet{
+		comparison(operatorGE, v1),
+		comparison(operatorLE, v2),
+	}
+}
+
+func cr2op(i item, o Option) node {
+	if i.typ == itemXRange {
+		v, n, err := partial(i.val)
+		if err != nil {
+			return shift(i, err)
+		}
+		switch {
+		case n < 2:
+			return xr2op(i, o)
+		case v.major > 0:
+			return below(lower(v, o), Major)
+		default:
+			return below(lower(v, o), Minor)
+		}
+	}
+	v1, err := parseVersion(i)
+	if err != nil {
+		return err
+	}
+	switch {
+	case v1.major > 0:
+		return below(v1, Major)
+	case v1.minor > 0:
+		return below(v1, Minor)
+	default:
+		return below(v1, Patch)
+	}
+}
+
+func tld2op(i item, o Option) node {
+	if i.typ == itemXRange {
+		return xr2op(i, o)
+	}
+	v1, err := parseVersion(i)
+	if err != nil {
+		return err
+	}
+	return below(v1, Minor)
+}
+
+// every returns the comparators of "*", which match every version.
+func every(o Option) nodeSet {
+	return nodeSet{comparison(operatorGE, lower(Version{}, o))}
+}
+
+func xr2op(i item, o Option) node {
+	v, n, err := partial(i.val)
+	if err != nil {
+		return shift(i, err)
+	}
+
+	switch n {
+	case 0:
+		return every(o)
+	case 1:
+		return below(lower(v, o), Major)
+	case 2:
+		return below(lower(v, o), Minor)
+	}
+	return nodeSet{
+		comparison(string(operatorEQ), &v),
+	}
+}
+
+// op2xr converts a comparator on an x-range, such as ">1.2" or "<=1.x", into a comparator on the
+// version bounding the x-range, matching npm: ">1.2" becomes ">=1.3.0" and "<=1.2" becomes "<1.3.0-0".
+// "=1.x" is the x-range itself, and "<*" or ">*" match nothing while "<=*" and ">=*" match "*".
+func op2xr(operator string, i item, o Option) node {
+	v, n, err := partial(i.val)
+	if err != nil {
+		return shift(i, err)
+	}
+
+	switch {
+	case n == 0 && (operator == string(operatorGT) || operator == string(operatorLT)):
+		return nodeSet{comparison(string(operatorLT), &Version{prerelease: "0"})}
+	case n == 0 || operator == string(operatorEQ):
+		return xr2op(i, o)
+	}
+	switch operator {
+	case string(operatorGT):
+		v.advance(n - 1)
+		operator = operatorGE
+	case operatorLE:
+		v.advance(n - 1)
+		operator = string(operatorLT)
+	}
+	if operator == operatorGE {
+		return nodeSet{comparison(operator, lower(v, o))}
+	}
+	v.prerelease = "0"
+	return nodeSet{comparison(operator, &v)}
+}
+
+// lower returns the inclusive lower bound of a desugared range starting at v.
+// It admits the prereleases of v when those are included.
+func lower(v Version, o Option) *Version {
+	if o&IncludePrerelease != 0 {
+		v.prerelease = "0"
+	}
+	return &v
+}
+
+// below returns the comparators of a desugared range from v1 up to the next level release
+// of its major.minor.patch. The exclusive upper bound sorts before every prerelease of that
+// release, so those never match.
+func below(v1 *Version, level Release) nodeSet {
+	next, _ := v1.release().Bump(level, "")
+	next.prerelease = "0"
+	return nodeSet{
+		comparison(operatorGE, v1),
+		comparison(string(operatorLT), next),
+	}
+}
+
+// partial splits an x-range such as "1.2.x" into its version numbers, which are 0 from the
+// first wildcard on, returning how many leading numbers were given before the wildcard.
+func partial(version string) (v Version, n int, err error) {
+	decimals := []string{"0", "0", "0"}
+	offset := 0
+	for _, s := range strings.SplitN(version, dot, 3) {
+		if strings.ContainsAny(s, wildcards) {
+			break
+		}
+		if err = checkNumber(version, offset, s); err != nil {
+			return v, n, err
+		}
+		decimals[n] = s
+		offset += len(s) + 1
+		n++
+	}
+	v.setDecimals(decimals)
+	return v, n, nil
+}
@@ -1,15 +1,13 @@
-package parser
+package semver
 
 import (
 	"fmt"
 	"testing"
-
-	"github.com/hansrodtang/semver"
 )
 
 func TestComparators(t *testing.T) {
-	ver1 := semver.Build(1, 2, 4)
-	ver2 := semver.Build(3, 2, 1)
+	ver1 := Build(1, 2, 4)
+	ver2 := Build(3, 2, 1)
 
 	expected := false
 	if response := gt(ver1, ver2); response != expected {
@@ -40,23 +38,35 @@ func TestComparators(t *testing.T) {
 func TestComparatorFunc(t *testing.T) {
 
 	v := satisfactionMap{
-		semver.Build(1, 2, 0): gte,
-		semver.Build(3, 3, 1): lte,
-		semver.Build(3, 2, 1): eq,
+		Build(1, 2, 0): gte,
+		Build(3, 3, 1): lte,
+		Build(3, 2, 1): eq,
 	}
-	ver := semver.Build(3, 2, 1)
+	ver := Build(3, 2, 1)
 
 	expected := true
 	for v, f := range v {
 		response := f(ver, v)
 		if !response {
-			t.Errorf("%v(%q,%q): => %t, want %t", getFunctionName(f), ver, v, response, expected)
+			t.Errorf("comparator(%q,%q): => %t, want %t", ver, v, response, expected)
 		}
 	}
 }
 
 func TestXRangesConverter(t *testing.T) {
-	i := item{itemAdvanced, "1.x"}
-	c := xr2op(i)
+	i := item{typ: itemXRange, val: "1.x"}
+	c := xr2op(i, 0)
 	fmt.Println(c)
 }
+
+func TestPartial(t *testing.T) {
+	partials := map[string]int{"*": 0, "1.x": 1, "1.2": 2, "0.0.X": 2}
+	for version, expected := range partials {
+		if _, n, err := partial(version); err != nil || n != expected {
+			t.Errorf("partial(%q): => %d, %v, want %d, <nil>", version, n, err, expected)
+		}
+	}
+	if v, n, err := partial("99999999999999999999.x"); err != nil || n != 1 || v.String() != "99999999999999999999.0.0" {
+		t.Errorf("partial(%q): => %q, %d, %v, want %q, 1, <nil>", "99999999999999999999.x", v, n, err, "99999999999999999999.0.0")
+	}
+}
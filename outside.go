@@ -0,0 +1,31 @@
+package semver
+
+// Outside reports whether v is higher than every version matching r if hilo is '>', or lower than
+// every version matching r if hilo is '<', like npm's semver.outside. That holds for every v if r
+// matches no version at all. Prereleases count as they would in Check, so "2.0.0-alpha" is higher
+// than "<2.0.0", which only releases below 2.0.0 match.
+// It panics if hilo is neither '<' nor '>'.
+func Outside(v *Version, r *Range, hilo rune) bool {
+	var operator string
+	switch hilo {
+	case operatorGT:
+		operator = operatorGE
+	case operatorLT:
+		operator = operatorLE
+	default:
+		panic("semver: Outside: hilo must be '<' or '>', not " + string(hilo))
+	}
+	// v is outside r if no version on its side of it, v included, matches r.
+	side := nodeRange{sets: []nodeSet{{comparison(operator, v)}}, options: IncludePrerelease}
+	return len(intersect(r.root.(nodeRange), side).sets) == 0
+}
+
+// Gtr reports whether v is higher than every version matching r, like npm's semver.gtr.
+func Gtr(v *Version, r *Range) bool {
+	return Outside(v, r, operatorGT)
+}
+
+// Ltr reports whether v is lower than every version matching r, like npm's semver.ltr.
+func Ltr(v *Version, r *Range) bool {
+	return Outside(v, r, operatorLT)
+}
@@ -0,0 +1,113 @@
+package semver_test
+
+import (
+	"testing"
+
+	"github.com/hansrodtang/semver"
+)
+
+type outside struct {
+	rng, version string
+	options      semver.Option
+	hilo         rune
+	expected     bool
+}
+
+// Cases partly adapted from the test fixtures of npm's semver.gtr and semver.ltr.
+var outsides = []outside{
+	{"~1.2.2", "1.3.0", 0, '>', true},
+	{"~0.6.1-1", "0.7.1-1", 0, '>', true},
+	{"1.0.0 - 2.0.0", "2.0.1", 0, '>', true},
+	{"1.0.0", "1.0.1-beta1", 0, '>', true},
+	{"1.0.0", "2.0.0", 0, '>', true},
+	{"<=2.0.0", "2.1.1", 0, '>', true},
+	{"<2.0.0", "2.0.0", 0, '>', true},
+	{"0.1.20 || 1.2.4", "1.2.5", 0, '>', true},
+	{"1.2.x || 2.x", "3.0.0", 0, '>', true},
+	{"~1.0", "1.1.2", 0, '>', true},
+	{"<0.7.0", "0.7.2", 0, '>', true},
+	{"~0.6.1-1", "0.6.1-1", 0, '>', false},
+	{"1.0.0 - 2.0.0", "1.2.3", 0, '>', false},
+	{"1.0.0 - 2.0.0", "0.9.9", 0, '>', false},
+	{"*", "1.2.3", 0, '>', false},
+	{">=1.0.0", "1.1.0", 0, '>', false},
+	{"<=2.0.0", "1.9999.9999", 0, '>', false},
+	{"^1", "1.0.0-0", 0, '>', false},
+	{"^0.1.0 || ~3.0.1 || 5.0.0", "1.0.0", 0, '>', false},
+
+	{"~1.2.2", "1.2.1", 0, '<', true},
+	{"1.0.0 - 2.0.0", "0.0.1", 0, '<', true},
+	{"1.0.0-beta.2", "1.0.0-beta.1", 0, '<', true},
+	{"1.0.0", "0.0.0", 0, '<', true},
+	{">1.0.0", "1.0.0", 0, '<', true},
+	{"1.2.x || 2.x", "1.1.3", 0, '<', true},
+	{"^1.0.0", "1.0.0-rc1", 0, '<', true},
+	{"^1.2.3-rc2", "1.2.3-rc1", 0, '<', true},
+	{">=1.0.0", "1.0.0", 0, '<', false},
+	{"<=2.0.0", "0.0.0", 0, '<', false},
+	{"^1.0.0", "2.0.0", 0, '<', false},
+	{"^1.0.0 || ~2.0.1", "2.0.0", 0, '<', false},
+	{"^1.0.0 || ~2.0.1", "2.0.0", 0, '>', false},
+
+	// Prereleases the range leaves out don't count as matching it.
+	{"<2.0.0", "2.0.0-alpha", 0, '>', true},
+	{"<2.0.0", "2.0.0-alpha", semver.IncludePrerelease, '>', false},
+	{">=1.0.0 <2.0.0", "1.5.0-beta", 0, '>', false},
+	{">1.0.0", "1.0.1-0", 0, '<', true},
+	{">1.0.0", "1.0.1-0", semver.IncludePrerelease, '<', false},
+	{"<1.2.3-rc.1 >=1.2.3-beta", "1.2.3-rc.1", 0, '>', true},
+	{">1.2.0 <=1.2.1-rc", "1.0.0", 0, '>', false},
+	{">1.2.0 <=1.2.1-rc", "1.0.0", 0, '<', true},
+	{">1.2.0 <=1.2.1-rc", "1.2.1-0", 0, '<', false},
+	{">1.2.0 <=1.2.1-rc", "1.2.1-0", 0, '>', false},
+	{">1.2.0 <=1.2.1-rc", "1.2.1", 0, '>', true},
+	{">1.2.0 <=1.2.1-rc", "1.2.1", 0, '<', false},
+
+	// A range matching nothing has every version outside it.
+	{">2.0.0 <1.0.0", "1.5.0", 0, '>', true},
+	{">2.0.0 <1.0.0", "1.5.0", 0, '<', true},
+}
+
+func TestOutside(t *testing.T) {
+	for _, c := range outsides {
+		r := semver.MustParseRange(c.rng, c.options)
+		v, _ := semver.New(c.version)
+		if result := semver.Outside(v, r, c.hilo); result != c.expected {
+			t.Errorf("Outside(%q, %q, %q) => %t, want %t", v, r, c.hilo, result, c.expected)
+		}
+		name, f := "Ltr", semver.Ltr
+		if c.hilo == '>' {
+			name, f = "Gtr", semver.Gtr
+		}
+		if result := f(v, r); result != c.expected {
+			t.Errorf("%v(%q, %q) => %t, want %t", name, v, r, result, c.expected)
+		}
+	}
+}
+
+func TestOutsidePanic(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Outside with hilo '=' did not panic")
+		}
+	}()
+	semver.Outside(semver.Build(1, 2, 3), semver.MustParseRange("*"), '=')
+}
+
+// TestOutsideMatches checks that no version matching a range is on the side of a version outside it.
+func TestOutsideMatches(t *testing.T) {
+	versions := matchVersions()
+	for _, r := range matchRanges(0, semver.IncludePrerelease) {
+		for _, v := range versions {
+			gtr, ltr := semver.Gtr(v, r.Range), semver.Ltr(v, r.Range)
+			if r.Check(v) && (gtr || ltr) {
+				t.Errorf("%q.Check(%q) with options %d => true, but Gtr => %t and Ltr => %t", r, v, r.options, gtr, ltr)
+			}
+			for _, w := range versions {
+				if r.Check(w) && ((gtr && w.Compare(v) >= 0) || (ltr && w.Compare(v) <= 0)) {
+					t.Errorf("%q.Check(%q) with options %d => true, but Gtr(%q) => %t and Ltr(%q) => %t", r, w, r.options, v, gtr, v, ltr)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package semver
+
+type parser struct {
+	l       *lexer
+	result  node
+	ibuf    []item
+	pos     int
+	options Option
+}
+
+func (p *parser) run() (node, error) {
+
+	n := handleRange(p)
+	if n.Type() != errorNode {
+		return n, nil
+	}
+	err := n.(nodeError).err
+	err.Input = p.l.input
+	return nil, err
+
+}
+
+func (p *parser) next() item {
+	if p.pos >= len(p.ibuf) {
+		i := p.l.nextItem()
+		p.ibuf = append(p.ibuf, i)
+		p.pos++
+		return i
+	}
+	i := p.ibuf[p.pos]
+	p.pos++
+	return i
+}
+
+func (p *parser) backup() {
+	p.pos--
+}
+
+// parseRange lexes and parses a range string into its node tree.
+func parseRange(input string, options Option) (node, error) {
+	l := lex(input)
+	p := &parser{l, nil, []item{}, 0, options}
+	return p.run()
+
+}
+
+// parseVersion parses the version operand of a comparator, converting
+// failures into an error node so they surface from parseRange.
+func parseVersion(i item) (*Version, node) {
+	if i.typ != itemVersion {
+		return nil, unexpected(i)
+	}
+	v, err := New(i.val)
+	if err != nil {
+		return nil, shift(i, err)
+	}
+	return v, nil
+}
+
+// unexpected converts an item that is not allowed at its position into an error node.
+func unexpected(i item) node {
+	kind := InvalidCharacter
+	switch {
+	case i.typ == itemXRange:
+		kind = MissingComponent
+	case i.val == "":
+		kind = UnexpectedEnd
+	}
+	return nodeError{&ParseError{"", i.pos, i.val, kind}}
+}
+
+// shift converts a *ParseError reported for the value of an item into an
+// error node, moving its offset to the item's position in the range.
+func shift(i item, err error) node {
+	e := *err.(*ParseError)
+	e.Offset += i.pos
+	return nodeError{&e}
+}
+
+func handleOperator(p *parser) node {
+	i := p.next()
+
+	switch i.typ {
+	case itemVersion:
+		ver1, err := parseVersion(i)
+		if err != nil {
+			return err
+		}
+		if i = p.next(); i.typ == itemAdvanced && i.val == string(operatorHY) {
+			ver2, err := parseVersion(p.next())
+			if err != nil {
+				return err
+			}
+			return hy2op(ver1, ver2)
+		}
+		p.backup()
+		return nodeSet{comparison(string(operatorEQ), ver1)}
+	case itemAdvanced:
+		switch i.val {
+		case string(operatorTR):
+			return tld2op(p.next(), p.options)
+		case string(operatorCR):
+			return cr2op(p.next(), p.options)
+		}
+		return unexpected(i)
+	case itemXRange:
+		return xr2op(i, p.options)
+	case itemOperator:
+		if operand := p.next(); operand.typ == itemXRange {
+			return op2xr(i.val, operand, p.options)
+		}
+		p.backup()
+		ver, err := parseVersion(p.next())
+		if err != nil {
+			return err
+		}
+		return nodeSet{comparison(i.val, ver)}
+	default:
+		return unexpected(i)
+	}
+}
+
+func handleSet(p *parser) node {
+	var set nodeSet
+
+	for {
+		i := p.next()
+
+		switch i.typ {
+		case itemSet:
+			break
+		case itemEOF, itemRange:
+			if i.typ == itemEOF {
+				p.backup()
+			}
+			if len(set) == 0 {
+				// An empty comparator set matches every version, as in npm.
+				return every(p.options)
+			}
+			return set
+		default:
+			p.backup()
+			nc := handleOperator(p)
+			if nc.Type() == errorNode {
+				return nc
+			}
+			set = append(set, nc.(nodeSet)...)
+
+		}
+	}
+}
+
+func handleRange(p *parser) node {
+	rng := nodeRange{options: p.options}
+
+	for {
+		i := p.next()
+		switch i.typ {
+		case itemError:
+			return unexpected(i)
+		case itemEOF:
+			if len(rng.sets) == 0 {
+				// So does an empty range.
+				rng.sets = append(rng.sets, every(p.options))
+			}
+			return rng
+		default:
+			p.backup()
+			ns := handleSet(p)
+			if ns.Type() == errorNode {
+				return ns
+			}
+			rng.sets = append(rng.sets, ns.(nodeSet))
+
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package semver
+
+import (
+	"runtime"
+	"testing"
+)
+
+type test struct {
+	expected bool
+	version  *Version
+}
+
+var parsables = map[string][]test{
+	"1.2.7 || >=1.2.9 <2.0.0": {
+		{true, Build(1, 2, 7)},
+		{true, Build(1, 2, 9)},
+		{true, Build(1, 4, 6)},
+		{false, Build(1, 2, 8)},
+		{false, Build(2, 0, 0)},
+	},
+	"1.2 <1.2.9 || >2.0.0": {
+		{false, Build(1, 2, 10)},
+		{false, Build(1, 5, 1)},
+		{true, Build(1, 2, 8)},
+		{true, Build(1, 2, 7)},
+	},
+	"* || >2.0.0": {
+		{true, Build(1, 0, 10)},
+		{true, Build(100, 5, 1)},
+		{true, Build(1, 100, 8)},
+		{true, Build(1, 2, 100)},
+	},
+	"* >2.0.0": {
+		{false, Build(1, 0, 10)},
+		{true, Build(100, 5, 1)},
+		{false, Build(1, 100, 8)},
+		{false, Build(1, 2, 100)},
+	},
+	"1.0.0 - 2.0.0": {
+		{false, Build(0, 0, 10)},
+		{false, Build(3, 5, 1)},
+		{true, Build(1, 1, 5)},
+		{true, Build(1, 9, 7)},
+	},
+	"~1.2.3": {
+		{false, Build(1, 3, 2)},
+		{false, Build(1, 2, 2)},
+		{true, Build(1, 2, 5)},
+		{true, Build(1, 2, 9)},
+	},
+	"^1.2.3": {
+		{true, Build(1, 2, 3)},
+		{true, Build(1, 9, 0)},
+		{false, Build(1, 2, 2)},
+		{false, Build(2, 0, 0)},
+	},
+	"^0.2.3": {
+		{true, Build(0, 2, 3)},
+		{true, Build(0, 2, 9)},
+		{false, Build(0, 3, 0)},
+		{false, Build(0, 2, 2)},
+	},
+	"^0.0.3": {
+		{true, Build(0, 0, 3)},
+		{false, Build(0, 0, 4)},
+		{false, Build(0, 0, 2)},
+	},
+	"^1.x": {
+		{true, Build(1, 0, 0)},
+		{true, Build(1, 9, 9)},
+		{false, Build(2, 0, 0)},
+		{false, Build(0, 9, 9)},
+	},
+	"^0.x": {
+		{true, Build(0, 0, 0)},
+		{true, Build(0, 9, 9)},
+		{false, Build(1, 0, 0)},
+	},
+	"^1.2.x": {
+		{true, Build(1, 2, 0)},
+		{true, Build(1, 5, 0)},
+		{false, Build(1, 1, 9)},
+		{false, Build(2, 0, 0)},
+	},
+	"^0.2.x": {
+		{true, Build(0, 2, 0)},
+		{false, Build(0, 3, 0)},
+	},
+	"^0.0": {
+		{true, Build(0, 0, 0)},
+		{true, Build(0, 0, 9)},
+		{false, Build(0, 1, 0)},
+	},
+	"^1.2.3-beta.2": {
+		{true, Build(1, 2, 3, []string{"beta", "2"})},
+		{true, Build(1, 2, 3)},
+		{true, Build(1, 9, 9)},
+		{false, Build(1, 2, 3, []string{"beta", "1"})},
+		{false, Build(2, 0, 0)},
+	},
+	"~1.2.3-beta.2": {
+		{true, Build(1, 2, 3, []string{"beta", "2"})},
+		{true, Build(1, 2, 9)},
+		{false, Build(1, 3, 0)},
+	},
+	">=1.0.0": {
+		{true, Build(2, 0, 0)},
+		{false, Build(2, 0, 0, []string{"alpha"})},
+		{false, Build(1, 0, 0, []string{"alpha"})},
+	},
+	">=1.2.3-alpha.1 <2.0.0": {
+		{true, Build(1, 2, 3, []string{"alpha", "2"})},
+		{true, Build(1, 5, 0)},
+		{false, Build(1, 2, 4, []string{"alpha"})},
+		{false, Build(1, 2, 3, []string{"alpha", "0"})},
+	},
+	"~1.2.3 || 1.3.0-beta": {
+		{false, Build(1, 2, 4, []string{"beta"})},
+		{false, Build(1, 3, 0, []string{"alpha"})},
+		{true, Build(1, 3, 0, []string{"beta"})},
+	},
+	"~1.2": {
+		{false, Build(1, 3, 2)},
+		{false, Build(1, 1, 9)},
+		{true, Build(1, 2, 3)},
+		{true, Build(1, 2, 9)},
+	},
+}
+
+func TestParser(t *testing.T) {
+
+	for k, v := range parsables {
+		n, err := parseRange(k, 0)
+		if err != nil {
+			t.Error(err)
+		} else {
+			for _, x := range v {
+				if response := n.Run(x.version); response != x.expected {
+					t.Errorf("%q.Run(%q) => %t, want %t", k, x.version, response, x.expected)
+				}
+			}
+		}
+	}
+}
+
+var prereleaseParsables = map[string][]test{
+	">=1.0.0": {
+		{true, Build(2, 0, 0, []string{"alpha"})},
+		{false, Build(1, 0, 0, []string{"alpha"})},
+	},
+	"~1.2.3": {
+		{true, Build(1, 2, 4, []string{"beta"})},
+		{false, Build(1, 3, 0, []string{"alpha"})},
+	},
+	"1.x": {
+		{true, Build(1, 0, 0, []string{"alpha"})},
+		{false, Build(2, 0, 0, []string{"alpha"})},
+	},
+	"^0.2": {
+		{true, Build(0, 2, 0, []string{"rc", "1"})},
+		{false, Build(0, 3, 0, []string{"rc", "1"})},
+	},
+	"*": {
+		{true, Build(0, 0, 0, []string{"alpha"})},
+	},
+}
+
+func TestParserIncludePrerelease(t *testing.T) {
+	for k, v := range prereleaseParsables {
+		n, err := parseRange(k, IncludePrerelease)
+		if err != nil {
+			t.Error(err)
+		} else {
+			for _, x := range v {
+				if response := n.Run(x.version); response != x.expected {
+					t.Errorf("%q.Run(%q) => %t, want %t", k, x.version, response, x.expected)
+				}
+			}
+		}
+	}
+}
+
+func TestParserGoroutines(t *testing.T) {
+	before := runtime.NumGoroutine()
+	for n := 0; n < 100; n++ {
+		parseRange("1.2.3 || 01.2.3 || >=1.0.0", 0)
+		parseRange("~ 1.2.3", 0)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("parseRange() left %d goroutines running, want 0", after-before)
+	}
+}
+
+func BenchmarkParser(b *testing.B) {
+	const VERSION = "1.2.7 || >=1.2.9 <2.0.0"
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		parseRange(VERSION, 0)
+	}
+}
+
+func BenchmarkParserError(b *testing.B) {
+	const VERSION = "01.2.7 || >=1.2.9 <2.0.0"
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		parseRange(VERSION, 0)
+	}
+}
+
+func BenchmarkRunner(b *testing.B) {
+	const VERSION = "1.2.7 || >=1.2.9 <2.0.0"
+	p, _ := parseRange(VERSION, 0)
+	v := Build(2, 0, 0)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		p.Run(v)
+	}
+}
@@ -0,0 +1,146 @@
+package semver
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Option alters how a Range matches versions.
+type Option int
+
+const (
+	// IncludePrerelease lets prerelease versions match every comparator they satisfy.
+	// By default a prerelease only matches a comparator set if one of its comparators
+	// carries a prerelease on the same major.minor.patch, so ">=1.0.0" does not match "2.0.0-alpha".
+	IncludePrerelease Option = 1 << iota
+)
+
+// Range is a parsed set of comparators, such as ">=1.2.7 <1.3.0 || 2.x".
+// A Range is safe to reuse and to share between goroutines once parsed.
+type Range struct {
+	root node
+}
+
+// ParseRange accepts a set of comparators and version numbers as a string and returns a Range.
+// The syntax for the string is documented here: https://www.npmjs.org/doc/misc/semver.html
+// Options alter how the Range matches versions, see Option.
+// Returns error if the supplied string is an invalid range.
+func ParseRange(input string, options ...Option) (*Range, error) {
+	var o Option
+	for _, option := range options {
+		o |= option
+	}
+	n, err := parseRange(input, o)
+	if err != nil {
+		return nil, err
+	}
+	return &Range{n}, nil
+}
+
+// MustParseRange is like ParseRange but panics if the supplied string is an invalid range.
+// It simplifies safe initialization of global variables holding ranges.
+func MustParseRange(input string, options ...Option) *Range {
+	r, err := ParseRange(input, options...)
+	if err != nil {
+		panic(fmt.Sprintf("semver: ParseRange(%q): %v", input, err))
+	}
+	return r
+}
+
+// Check returns true if the supplied Version matches the Range, false if it does not.
+func (r *Range) Check(v *Version) bool {
+	return r.root.Run(v)
+}
+
+// String returns the comparators contained in the Range in a normal form. Shorthands are spelled out as
+// the comparators they stand for, each set lists its lower bounds first and build metadata is left out,
+// so "~1.2.3" and "<1.3.0-0 >=1.2.3+build" both read ">=1.2.3 <1.3.0-0". Ranges matching the same
+// versions with different comparators, such as "<=1.2.3" and "<1.2.4-0", still read differently.
+// See Sugared for the shorthand form.
+func (r *Range) String() string {
+	return r.root.String()
+}
+
+// MinVersion returns the lowest version matching r, or nil if r matches no version at all.
+// The version is derived from the bounds of r, like npm's semver.minVersion. With IncludePrerelease
+// the lowest version above 1.2.3 is 1.2.4-0 rather than 1.2.4.
+func (r *Range) MinVersion() *Version {
+	return r.root.(nodeRange).min()
+}
+
+// MaxSatisfying returns the highest version in versions that matches r, or nil if none does.
+// Versions lower than the best match so far aren't checked against r.
+func MaxSatisfying(versions Versions, r *Range) *Version {
+	var max *Version
+	for _, v := range versions {
+		if (max == nil || v.Compare(max) > 0) && r.Check(v) {
+			max = v
+		}
+	}
+	return max
+}
+
+// MinSatisfying returns the lowest version in versions that matches r, or nil if none does.
+// Versions higher than the best match so far aren't checked against r.
+func MinSatisfying(versions Versions, r *Range) *Version {
+	var min *Version
+	for _, v := range versions {
+		if (min == nil || v.Compare(min) < 0) && r.Check(v) {
+			min = v
+		}
+	}
+	return min
+}
+
+// Simplify returns a shorter range that matches the same of the given versions as r, like npm's
+// semver.simplifyRange. Each run of sorted versions matching r becomes a single version or a hyphen
+// range, left open below the lowest and above the highest of versions.
+// It returns r if the result isn't shorter when Sugared, or if it would match different versions,
+// as it may for prereleases.
+func Simplify(versions Versions, r *Range) *Range {
+	sorted := append(Versions(nil), versions...)
+	sort.Sort(sorted)
+	matches := make([]bool, len(sorted))
+	for i, v := range sorted {
+		matches[i] = r.Check(v)
+	}
+
+	o := r.root.(nodeRange).options
+	simplified := nodeRange{options: o}
+	for i := 0; i < len(sorted); i++ {
+		if !matches[i] {
+			continue
+		}
+		j := i
+		for j+1 < len(sorted) && matches[j+1] {
+			j++
+		}
+		min, max := sorted[i], sorted[j]
+		var set nodeSet
+		switch {
+		case j == len(sorted)-1 && i == 0:
+			set = every(o)
+		case j == len(sorted)-1:
+			set = nodeSet{comparison(operatorGE, min)}
+		case i == j:
+			set = nodeSet{comparison(string(operatorEQ), min)}
+		case i == 0:
+			set = nodeSet{comparison(operatorLE, max)}
+		default:
+			set = nodeSet{comparison(operatorGE, min), comparison(operatorLE, max)}
+		}
+		simplified.sets = append(simplified.sets, set)
+		i = j
+	}
+
+	result := &Range{simplified}
+	for i, v := range sorted {
+		if result.Check(v) != matches[i] {
+			return r
+		}
+	}
+	if len(result.Sugared()) < len(r.Sugared()) {
+		return result
+	}
+	return r
+}
@@ -0,0 +1,327 @@
+package semver_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hansrodtang/semver"
+)
+
+var rangeStrings = map[string]string{
+	"1.2.7 || >=1.2.9 <2.0.0": "=1.2.7 || >=1.2.9 <2.0.0",
+	"1.0.0 - 2.0.0":           ">=1.0.0 <=2.0.0",
+	"~1.2.3":                  ">=1.2.3 <1.3.0-0",
+	"<=1.2.3":                 "<=1.2.3",
+
+	// Sets list lower bounds, equalities and upper bounds, each by version, without build metadata.
+	"<1.3.0-0 >=1.2.3+build":        ">=1.2.3 <1.3.0-0",
+	"=1.2.3+build":                  "=1.2.3",
+	"<2.0.0 1.5.0 >=1.0.0":          ">=1.0.0 =1.5.0 <2.0.0",
+	"<=2.0.0 <2.0.0 >1.0.0 >=1.0.0": ">=1.0.0 >1.0.0 <2.0.0 <=2.0.0",
+	"^1.2.0 >1.2.5 || <1.0.0":       ">=1.2.0 >1.2.5 <2.0.0-0 || <1.0.0",
+
+	// Comparators on x-ranges compare to the bounds of the x-range, as in npm.
+	">=2":    ">=2.0.0",
+	"<1.1":   "<1.1.0-0",
+	">1":     ">=2.0.0",
+	"<=1.2":  "<1.3.0-0",
+	">1.2.x": ">=1.3.0",
+	"<=1.x":  "<2.0.0-0",
+	"<1.x":   "<1.0.0-0",
+	">=1.x":  ">=1.0.0",
+	"=1.2":   ">=1.2.0 <1.3.0-0",
+	">=*":    ">=0.0.0",
+	"<=*":    ">=0.0.0",
+	"<*":     "<0.0.0-0",
+	">*":     "<0.0.0-0",
+
+	// An empty range or comparator set is "*", as in npm.
+	"":         ">=0.0.0",
+	" ":        ">=0.0.0",
+	"|| 1.2.3": ">=0.0.0 || =1.2.3",
+
+	// Version numbers have no size limit.
+	"~1.18446744073709551615.2":   ">=1.18446744073709551615.2 <1.18446744073709551616.0-0",
+	"^18446744073709551615.2.3":   ">=18446744073709551615.2.3 <18446744073709551616.0.0-0",
+	"99999999999999999999.x":      ">=99999999999999999999.0.0 <100000000000000000000.0.0-0",
+	"^0.0.99999999999999999999-1": ">=0.0.99999999999999999999-1 <0.0.100000000000000000000-0",
+}
+
+func TestParseRange(t *testing.T) {
+	for input, expected := range rangeStrings {
+		r, err := semver.ParseRange(input)
+		if err != nil {
+			t.Errorf("ParseRange(%q) => %v, want <nil>", input, err)
+			continue
+		}
+		if result := r.String(); result != expected {
+			t.Errorf("ParseRange(%q).String() => %q, want %q", input, result, expected)
+		}
+	}
+
+	included := map[string]string{
+		">=2":  ">=2.0.0-0",
+		">1.2": ">=1.3.0-0",
+		"<1.1": "<1.1.0-0",
+		">=*":  ">=0.0.0-0",
+		"":     ">=0.0.0-0",
+	}
+	for input, expected := range included {
+		if result := semver.MustParseRange(input, semver.IncludePrerelease).String(); result != expected {
+			t.Errorf("ParseRange(%q) with IncludePrerelease => %q, want %q", input, result, expected)
+		}
+	}
+}
+
+var sugaredStrings = map[string]string{
+	"*":                              "*",
+	"":                               "*",
+	"<0.0.0-0":                       "<0.0.0-0",
+	">=0.0.0 <2.0.0":                 "* <2.0.0",
+	"=1.2.3 || 1.2.4":                "1.2.3 || 1.2.4",
+	">=1.2.3 <=1.4.0":                "1.2.3 - 1.4.0",
+	">=1.2.3 <1.3.0-0":               "~1.2.3",
+	">=1.2.3 <2.0.0-0":               "^1.2.3",
+	">=0.2.3 <0.3.0-0":               "^0.2.3",
+	">=0.0.3 <0.0.4-0":               "^0.0.3",
+	">=0.0.3 <0.1.0-0":               "~0.0.3",
+	"^1.2.3-beta+build":              "^1.2.3-beta+build",
+	"~1.2 || 2 || 0.x":               "1.2.x || 2.x || 0.x",
+	"^1.2 >1.2.5":                    "^1.2.0 >1.2.5",
+	">=1.2.3 <1.3.0":                 ">=1.2.3 <1.3.0",
+	"<1.3.0-0 >=1.2.3":               "<1.3.0-0 >=1.2.3",
+	"^99999999999999999999.2.3":      "^99999999999999999999.2.3",
+	"18446744073709551615.x":         "18446744073709551615.x",
+	"~1.2.3 1.0.0 - 2.0.0 || <1.0.0": "~1.2.3 1.0.0 - 2.0.0 || <1.0.0",
+}
+
+func TestSugared(t *testing.T) {
+	for input, expected := range sugaredStrings {
+		r := semver.MustParseRange(input)
+		if result := r.Sugared(); result != expected {
+			t.Errorf("%q.Sugared() => %q, want %q", r, result, expected)
+		}
+	}
+
+	included := map[string]string{
+		"*":                "*",
+		"1.x":              "1.x",
+		">=1.2.0 <2.0.0-0": "^1.2.0",
+		">=0.0.0":          ">=0.0.0",
+	}
+	for input, expected := range included {
+		r := semver.MustParseRange(input, semver.IncludePrerelease)
+		if result := r.Sugared(); result != expected {
+			t.Errorf("%q.Sugared() with IncludePrerelease => %q, want %q", r, result, expected)
+		}
+	}
+
+	for _, input := range append([]string{"1.x || 2.3.x", "^0.0.1 ~0.1", "1.2.3 - 2.0.0 || 3.0.0"}, intersectRanges...) {
+		for _, o := range []semver.Option{0, semver.IncludePrerelease} {
+			r := semver.MustParseRange(input, o)
+			if result := semver.MustParseRange(r.Sugared(), o).String(); result != r.String() {
+				t.Errorf("ParseRange(%q.Sugared()) with options %d => %q, want %q", r, o, result, r.String())
+			}
+		}
+	}
+}
+
+func TestParseRangeError(t *testing.T) {
+	for _, input := range badRequirements {
+		if r, err := semver.ParseRange(input); err == nil {
+			t.Errorf("ParseRange(%q) => %v, want Error", input, r)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	for _, c := range satisfactions {
+		r := semver.MustParseRange(c.requirements)
+		ver, _ := semver.New(c.version)
+		if result := r.Check(ver); result != c.expected {
+			t.Errorf("%q.Check(%q) => %t, want %t", r, ver, result, c.expected)
+		}
+	}
+}
+
+func TestMustParseRange(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustParseRange(%q) did not panic", badRequirements[0])
+		}
+	}()
+	semver.MustParseRange(badRequirements[0])
+}
+
+type satisfying struct {
+	versions []string
+	rng      string
+	options  semver.Option
+	max, min string
+}
+
+// Cases partly taken from the test fixtures of npm's semver.maxSatisfying and semver.minSatisfying.
+var satisfyings = []satisfying{
+	{[]string{"1.2.3", "1.2.4"}, "1.2", 0, "1.2.4", "1.2.3"},
+	{[]string{"1.2.4", "1.2.3"}, "1.2", 0, "1.2.4", "1.2.3"},
+	{[]string{"1.2.3", "1.2.4", "1.2.5", "1.2.6"}, "~1.2.3", 0, "1.2.6", "1.2.3"},
+	{[]string{"1.1.0", "1.2.0", "1.2.1", "1.3.0", "2.0.0-b1", "2.0.0", "2.1.0"}, "~2.0.0", 0, "2.0.0", "2.0.0"},
+	{[]string{"1.2.2", "1.2.3-beta", "1.3.0-rc"}, "^1.2.0", 0, "1.2.2", "1.2.2"},
+	{[]string{"1.2.2", "1.2.3-beta", "1.3.0-rc"}, "^1.2.0", semver.IncludePrerelease, "1.3.0-rc", "1.2.2"},
+	{[]string{"1.2.3-beta.1", "1.2.3-beta.3", "1.2.4-beta.2"}, "^1.2.3-beta.2", 0, "1.2.3-beta.3", "1.2.3-beta.3"},
+	{[]string{"1.2.3", "2.0.0"}, ">3.0.0", 0, "", ""},
+	{nil, "*", 0, "", ""},
+}
+
+func TestMaxMinSatisfying(t *testing.T) {
+	for _, c := range satisfyings {
+		r := semver.MustParseRange(c.rng, c.options)
+		versions, _ := semver.ParseVersions(c.versions)
+		if result := versionString(semver.MaxSatisfying(versions, r)); result != c.max {
+			t.Errorf("MaxSatisfying(%q, %q) => %q, want %q", c.versions, r, result, c.max)
+		}
+		if result := versionString(semver.MinSatisfying(versions, r)); result != c.min {
+			t.Errorf("MinSatisfying(%q, %q) => %q, want %q", c.versions, r, result, c.min)
+		}
+	}
+}
+
+// Versions and cases taken from the test fixtures of npm's semver.simplifyRange.
+var simplifyVersions = []string{
+	"1.0.0", "1.0.1", "1.0.2", "1.0.3", "1.0.4", "1.1.0", "1.1.1", "1.1.2", "1.2.0", "1.2.1",
+	"1.2.2", "1.2.3", "1.2.4", "1.2.5", "2.0.0", "2.0.1", "2.1.0", "2.1.1", "2.1.2", "2.2.0",
+	"2.2.1", "2.2.2", "2.3.0", "2.3.1", "2.4.0", "3.0.0", "3.1.0", "3.2.0", "3.3.0",
+}
+
+var simplifications = map[string]string{
+	"1.x": "1.x",
+	"1.0.0 || 1.0.1 || 1.0.2 || 1.0.3 || 1.0.4": "<=1.0.4",
+	">=3.0.0 <3.1.0":                            "3.0.0",
+	"3.0.0 || 3.1 || 3.2 || 3.3":                ">=3.0.0",
+	"1 || 2 || 3":                               "*",
+	"2.1 || 2.2 || 2.3":                         "2.1.0 - 2.3.1",
+	"1.2.3 || 1.2.4 || 1.2.5 || >=1.3.0 <2.0.0": "1.2.3 - 1.2.5",
+	">5.0.0":                     ">5.0.0", // npm gives "", which would read as "*"
+	"^1.2.0 || 2.3.x || >=3.2.0": "^1.2.0 || 2.3.x || >=3.2.0",
+	"1.0.0 || 1.0.1 || 2.0.0 || 2.0.1 || 3.3.0": "<=1.0.1 || 2.0.0 - 2.0.1 || >=3.3.0",
+}
+
+func TestSimplify(t *testing.T) {
+	versions, _ := semver.ParseVersions(simplifyVersions)
+	for input, expected := range simplifications {
+		r := semver.MustParseRange(input)
+		if result := semver.Simplify(versions, r).Sugared(); result != expected {
+			t.Errorf("Simplify(versions, %q) => %q, want %q", r, result, expected)
+		}
+	}
+
+	// 1.1.0-beta matches r, but wouldn't match <=1.1.0, which leaves out prereleases.
+	versions, _ = semver.ParseVersions([]string{"2.0.0", "1.1.0", "1.1.0-beta", "1.0.0"})
+	r := semver.MustParseRange("1.0.0 || >=1.1.0-beta <2.0.0")
+	if result := semver.Simplify(versions, r); result != r {
+		t.Errorf("Simplify(%q, %q) => %q, want %q", versions, r, result, r)
+	}
+	r = semver.MustParseRange("1.0.0 || >=1.1.0-beta <2.0.0", semver.IncludePrerelease)
+	if result := semver.Simplify(versions, r).Sugared(); result != "<=1.1.0" {
+		t.Errorf("Simplify(%q, %q) with IncludePrerelease => %q, want %q", versions, r, result, "<=1.1.0")
+	}
+	if versions[0].String() != "2.0.0" {
+		t.Errorf("Simplify(%q, %q) sorted its versions", versions, r)
+	}
+}
+
+// versionString returns the string of v, or "" if v is nil.
+func versionString(v *semver.Version) string {
+	if v == nil {
+		return ""
+	}
+	return v.String()
+}
+
+// Cases adapted from the test fixtures of npm's semver.minVersion.
+var minVersions = map[string]string{
+	"*":                                "0.0.0",
+	"* || >=2.0.0":                     "0.0.0",
+	">2.0.0 || *":                      "0.0.0",
+	"1.0.0":                            "1.0.0",
+	"1.0":                              "1.0.0",
+	"1.0.x":                            "1.0.0",
+	"1.*.x":                            "1.0.0",
+	"1":                                "1.0.0",
+	"=1.0.0":                           "1.0.0",
+	"~1.1.1":                           "1.1.1",
+	"~1.1.1-beta":                      "1.1.1-beta",
+	"~1.1.1 || >=2.0.0":                "1.1.1",
+	"^1.1.1":                           "1.1.1",
+	"^1.1.1-beta":                      "1.1.1-beta",
+	"^2.16.2 ^2.16":                    "2.16.2",
+	"1.1.1 - 1.8.0":                    "1.1.1",
+	"<2.0.0":                           "0.0.0",
+	"<0.0.0-beta":                      "0.0.0-0",
+	"<0.0.1-beta":                      "0.0.0",
+	"<2.0.0 || >4.0.0":                 "0.0.0",
+	"<0.0.0-beta >0.0.0-alpha":         "0.0.0-alpha.0",
+	">=1.1.1 <2.0.0 || >=2.2.2 <2.0.0": "1.1.1",
+	">=2.2.2 <2.0.0 || >=1.1.1 <2.0.0": "1.1.1",
+	">1.0.0":                           "1.0.1",
+	">1.0.0-0":                         "1.0.0-0.0",
+	">1.0.0-beta":                      "1.0.0-beta.0",
+	">2.0.0 || >1.0.0-beta":            "1.0.0-beta.0",
+	">1.2.3+build <=1.2.4":             "1.2.4",
+	">1.2.3 || ^2.0.0-beta":            "1.2.4",
+	">4.0.0 <3.0.0":                    "",
+	"<0.0.0":                           "",
+	">=1.0.0 <=1.0.0-rc":               "",
+	">2.2.0 <=2.2.1-0":                 "2.2.1-0",
+	">=0.0.1-0 >0.0.0":                 "0.0.1-0",
+	">1.2.0 <=1.2.1-rc":                "1.2.1-0",
+	">1.2.0 <1.2.1-rc":                 "1.2.1-0",
+	">1.2.0 <1.2.1-0":                  "",
+}
+
+func TestMinVersion(t *testing.T) {
+	for input, expected := range minVersions {
+		r := semver.MustParseRange(input)
+		if result := versionString(r.MinVersion()); result != expected {
+			t.Errorf("%q.MinVersion() => %q, want %q", r, result, expected)
+		}
+		if min := r.MinVersion(); min != nil && !r.Check(min) {
+			t.Errorf("%q.Check(%q) => false, want true", r, min)
+		}
+	}
+
+	prereleases := map[string]string{
+		">1.2.3":    "1.2.4-0",
+		"^1.2.3":    "1.2.3",
+		"1.x":       "1.0.0-0",
+		"<0.0.0":    "0.0.0-0",
+		"<=0.0.0-0": "0.0.0-0",
+	}
+	for input, expected := range prereleases {
+		r := semver.MustParseRange(input, semver.IncludePrerelease)
+		if result := versionString(r.MinVersion()); result != expected {
+			t.Errorf("%q.MinVersion() with IncludePrerelease => %q, want %q", r, result, expected)
+		}
+	}
+}
+
+func BenchmarkCheck(b *testing.B) {
+	r := semver.MustParseRange("1.2.7 || >=1.2.9 <2.0.0")
+	v := semver.Build(2, 0, 0)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		r.Check(v)
+	}
+}
+
+func ExampleRange_Check() {
+	r := semver.MustParseRange(">=1.2.7 <1.3.0")
+	v, _ := semver.New("1.2.8")
+	// do something with error
+	if r.Check(v) {
+		fmt.Println("v is in range")
+	}
+	// Output: v is in range
+}
@@ -0,0 +1,194 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Release identifies the kind of change between two versions, as used by Bump and Diff.
+type Release int
+
+const (
+	None       Release = iota // 1.2.3 -> 1.2.3
+	Major                     // 1.2.3 -> 2.0.0
+	PreMajor                  // 1.2.3 -> 2.0.0-0
+	Minor                     // 1.2.3 -> 1.3.0
+	PreMinor                  // 1.2.3 -> 1.3.0-0
+	Patch                     // 1.2.3 -> 1.2.4
+	PrePatch                  // 1.2.3 -> 1.2.4-0
+	Prerelease                // 1.2.3 -> 1.2.4-0, 1.2.4-0 -> 1.2.4-1
+	Metadata                  // 1.2.3 -> 1.2.3+build
+)
+
+var releases = []string{
+	None:       "none",
+	Major:      "major",
+	PreMajor:   "premajor",
+	Minor:      "minor",
+	PreMinor:   "preminor",
+	Patch:      "patch",
+	PrePatch:   "prepatch",
+	Prerelease: "prerelease",
+	Metadata:   "metadata",
+}
+
+func (r Release) String() string {
+	if r < 0 || int(r) >= len(releases) {
+		return fmt.Sprintf("Release(%d)", int(r))
+	}
+	return releases[r]
+}
+
+// Bump returns the version following v for the given release, matching the behaviour of npm's semver.inc.
+// Major, Minor and Patch release a prerelease of that version or advance to the next one, clearing lower
+// numbers. The prerelease levels create or advance a prerelease, which is preid.0 if preid is given and
+// v does not already have a prerelease starting with preid and a number.
+// Metadata is never carried over. Version numbers and numerical prerelease identifiers are incremented
+// without limit. Returns error if preid isn't a valid prerelease identifier.
+func (v Version) Bump(level Release, preid string) (*Version, error) {
+	if preid != "" {
+		if end, err := scanIdentifiers(preid, 0, len(preid), true); err != nil {
+			return nil, err
+		} else if end < len(preid) {
+			return nil, invalidCharacter(preid, end)
+		}
+	}
+
+	next := v.release()
+	next.prerelease = v.prerelease
+
+	switch level {
+	case Major:
+		if next.minor != 0 || next.patch != 0 || next.prerelease == "" {
+			next.advance(0)
+		}
+		next.prerelease = ""
+	case PreMajor:
+		next.advance(0)
+		next.prerelease = ""
+		next.bumpPrerelease(preid)
+	case Minor:
+		if next.patch != 0 || next.prerelease == "" {
+			next.advance(1)
+		}
+		next.prerelease = ""
+	case PreMinor:
+		next.advance(1)
+		next.prerelease = ""
+		next.bumpPrerelease(preid)
+	case Patch:
+		if next.prerelease == "" {
+			next.advance(2)
+		}
+		next.prerelease = ""
+	case PrePatch:
+		next.advance(2)
+		next.prerelease = ""
+		next.bumpPrerelease(preid)
+	case Prerelease:
+		if next.prerelease == "" {
+			next.advance(2)
+		}
+		next.bumpPrerelease(preid)
+	default:
+		return nil, fmt.Errorf("unknown release: %v", level)
+	}
+	return &next, nil
+}
+
+// advance increments the major, minor or patch version for i 0, 1 or 2 and clears the numbers after it.
+// Numbers are incremented without limit.
+func (v *Version) advance(i int) {
+	v.increment(i)
+	for j := i + 1; j < 3; j++ {
+		v.setNumber(j, 0)
+	}
+}
+
+// Diff returns the most significant release between v and other, matching the behaviour of npm's semver.diff.
+// The release is PreMajor, PreMinor or PrePatch if the higher version is a prerelease, and Prerelease if only
+// the prerelease differs. A prerelease followed by its own release reports the level it releases, so 1.1.0-rc.1
+// to 1.1.0 is Minor. Versions of equal precedence differ in Metadata or not at all, which is None.
+func (v *Version) Diff(other *Version) Release {
+	c := v.Compare(other)
+	if c == 0 {
+		if v.metadata != other.metadata {
+			return Metadata
+		}
+		return None
+	}
+	high, low := v, other
+	if c < 0 {
+		high, low = other, v
+	}
+	_, i := v.compareNumbers(other)
+
+	if low.isPrerelease() && !high.isPrerelease() {
+		if low.minor == 0 && low.patch == 0 {
+			return Major
+		}
+		if i == 3 {
+			if low.minor != 0 && low.patch == 0 {
+				return Minor
+			}
+			return Patch
+		}
+	}
+	if i == 3 {
+		return Prerelease
+	}
+	if high.isPrerelease() {
+		return [...]Release{PreMajor, PreMinor, PrePatch}[i]
+	}
+	return [...]Release{Major, Minor, Patch}[i]
+}
+
+// bumpPrerelease increments the last numerical prerelease identifier, appending a 0 if there is none.
+// A preid replaces the prerelease with preid.0, unless the prerelease already starts with preid and a number.
+func (v *Version) bumpPrerelease(preid string) {
+	var identifiers []string
+	if v.prerelease != "" {
+		identifiers = strings.Split(v.prerelease, dot)
+	}
+
+	i := len(identifiers) - 1
+	for ; i >= 0 && !numerical(identifiers[i]); i-- {
+	}
+	if i >= 0 {
+		identifiers[i] = incrementNumber(identifiers[i])
+	} else {
+		identifiers = append(identifiers, "0")
+	}
+
+	if preid != "" && (identifiers[0] != preid || len(identifiers) < 2 || !numerical(identifiers[1])) {
+		identifiers = []string{preid, "0"}
+	}
+	v.prerelease = strings.Join(identifiers, dot)
+}
+
+// decrementNumber subtracts one from a positive decimal number of any length without leading zeroes.
+func decrementNumber(number string) string {
+	b := []byte(number)
+	i := len(b) - 1
+	for ; b[i] == '0'; i-- {
+		b[i] = '9'
+	}
+	b[i]--
+	if b[0] == '0' && len(b) > 1 {
+		b = b[1:]
+	}
+	return string(b)
+}
+
+// incrementNumber adds one to a decimal number of any length.
+func incrementNumber(number string) string {
+	b := []byte(number)
+	for i := len(b) - 1; i >= 0; i-- {
+		if b[i] < '9' {
+			b[i]++
+			return string(b)
+		}
+		b[i] = '0'
+	}
+	return "1" + string(b)
+}
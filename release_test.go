@@ -0,0 +1,161 @@
+package semver_test
+
+import (
+	"testing"
+
+	"github.com/hansrodtang/semver"
+)
+
+type bump struct {
+	version  string
+	level    semver.Release
+	preid    string
+	expected string
+}
+
+// Cases taken from the test fixtures of npm's semver.inc.
+var bumps = []bump{
+	{"1.2.3", semver.Major, "", "2.0.0"},
+	{"1.2.3", semver.Minor, "", "1.3.0"},
+	{"1.2.3", semver.Patch, "", "1.2.4"},
+	{"1.2.3-tag", semver.Major, "", "2.0.0"},
+	{"1.2.0-0", semver.Patch, "", "1.2.0"},
+	{"1.2.3-4", semver.Major, "", "2.0.0"},
+	{"1.2.3-4", semver.Minor, "", "1.3.0"},
+	{"1.2.3-4", semver.Patch, "", "1.2.3"},
+	{"1.2.3-alpha.0.beta", semver.Major, "", "2.0.0"},
+	{"1.2.3-alpha.0.beta", semver.Minor, "", "1.3.0"},
+	{"1.2.3-alpha.0.beta", semver.Patch, "", "1.2.3"},
+	{"1.2.4", semver.Prerelease, "", "1.2.5-0"},
+	{"1.2.3-0", semver.Prerelease, "", "1.2.3-1"},
+	{"1.2.3-alpha.0", semver.Prerelease, "", "1.2.3-alpha.1"},
+	{"1.2.3-alpha.1", semver.Prerelease, "", "1.2.3-alpha.2"},
+	{"1.2.3-alpha.0.beta", semver.Prerelease, "", "1.2.3-alpha.1.beta"},
+	{"1.2.3-alpha.10.0.beta", semver.Prerelease, "", "1.2.3-alpha.10.1.beta"},
+	{"1.2.3-alpha.10.beta.0", semver.Prerelease, "", "1.2.3-alpha.10.beta.1"},
+	{"1.2.3-alpha.9.beta", semver.Prerelease, "", "1.2.3-alpha.10.beta"},
+	{"1.2.3-alpha.99.beta", semver.Prerelease, "", "1.2.3-alpha.100.beta"},
+	{"1.2.3-beta", semver.Prerelease, "", "1.2.3-beta.0"},
+	{"1.2.0", semver.PrePatch, "", "1.2.1-0"},
+	{"1.2.0-1", semver.PrePatch, "", "1.2.1-0"},
+	{"1.2.0", semver.PreMinor, "", "1.3.0-0"},
+	{"1.2.3-1", semver.PreMinor, "", "1.3.0-0"},
+	{"1.2.0", semver.PreMajor, "", "2.0.0-0"},
+	{"1.2.3-1", semver.PreMajor, "", "2.0.0-0"},
+	{"1.2.0-1", semver.Minor, "", "1.2.0"},
+	{"1.0.0-1", semver.Major, "", "1.0.0"},
+
+	{"1.2.3", semver.Major, "dev", "2.0.0"},
+	{"1.2.3-tag", semver.Major, "dev", "2.0.0"},
+	{"1.2.4", semver.Prerelease, "dev", "1.2.5-dev.0"},
+	{"1.2.3-0", semver.Prerelease, "dev", "1.2.3-dev.0"},
+	{"1.2.3-alpha.0", semver.Prerelease, "dev", "1.2.3-dev.0"},
+	{"1.2.3-alpha.0", semver.Prerelease, "alpha", "1.2.3-alpha.1"},
+	{"1.2.3-alpha.0.beta", semver.Prerelease, "dev", "1.2.3-dev.0"},
+	{"1.2.3-alpha.0.beta", semver.Prerelease, "alpha", "1.2.3-alpha.1.beta"},
+	{"1.2.3-alpha.10.0.beta", semver.Prerelease, "alpha", "1.2.3-alpha.10.1.beta"},
+	{"1.2.3-alpha.10.beta.0", semver.Prerelease, "alpha", "1.2.3-alpha.10.beta.1"},
+	{"1.2.3-alpha.9.beta", semver.Prerelease, "alpha", "1.2.3-alpha.10.beta"},
+	{"1.2.0", semver.PrePatch, "dev", "1.2.1-dev.0"},
+	{"1.2.0-1", semver.PrePatch, "dev", "1.2.1-dev.0"},
+	{"1.2.0", semver.PreMinor, "dev", "1.3.0-dev.0"},
+	{"1.2.3-1", semver.PreMinor, "dev", "1.3.0-dev.0"},
+	{"1.2.0", semver.PreMajor, "dev", "2.0.0-dev.0"},
+	{"1.2.3-1", semver.PreMajor, "dev", "2.0.0-dev.0"},
+	{"1.2.3-dev.bar", semver.Prerelease, "dev", "1.2.3-dev.0"},
+
+	// The requests that motivated Bump
+	{"1.2.3-rc.1", semver.Prerelease, "rc", "1.2.3-rc.2"},
+	{"1.2.3", semver.Prerelease, "rc", "1.2.4-rc.0"},
+	{"1.2.3+build.5", semver.Patch, "", "1.2.4"},
+}
+
+func TestBump(t *testing.T) {
+	for _, c := range bumps {
+		ver, _ := semver.New(c.version)
+		result, err := ver.Bump(c.level, c.preid)
+		if err != nil {
+			t.Errorf("%q.Bump(%v, %q) => %v, want <nil>", ver, c.level, c.preid, err)
+			continue
+		}
+		if result.String() != c.expected {
+			t.Errorf("%q.Bump(%v, %q) => %q, want %q", ver, c.level, c.preid, result, c.expected)
+		}
+		if ver.String() != c.version {
+			t.Errorf("%q.Bump(%v, %q) changed the receiver to %q", c.version, c.level, c.preid, ver)
+		}
+	}
+}
+
+func TestBumpError(t *testing.T) {
+	ver := semver.Build(1, 2, 3)
+	for _, preid := range []string{"01", "rc+1", "r!c", "rc..1"} {
+		if result, err := ver.Bump(semver.Prerelease, preid); err == nil {
+			t.Errorf("%q.Bump(Prerelease, %q) => %q, want Error", ver, preid, result)
+		}
+	}
+	for _, level := range []semver.Release{semver.None, semver.Metadata, semver.Release(42)} {
+		if result, err := ver.Bump(level, ""); err == nil {
+			t.Errorf("%q.Bump(%v, %q) => %q, want Error", ver, level, "", result)
+		}
+	}
+}
+
+type diff struct {
+	v1, v2   string
+	expected semver.Release
+}
+
+// Cases taken from the test fixtures of npm's semver.diff.
+var diffs = []diff{
+	{"1.2.3", "0.2.3", semver.Major},
+	{"0.2.3", "1.2.3", semver.Major},
+	{"1.4.5", "0.2.3", semver.Major},
+	{"1.2.3", "2.0.0-pre", semver.PreMajor},
+	{"1.2.3", "1.3.3", semver.Minor},
+	{"1.0.1", "1.1.0-pre", semver.PreMinor},
+	{"1.2.3", "1.2.4", semver.Patch},
+	{"1.2.3", "1.2.4-pre", semver.PrePatch},
+	{"0.0.1", "0.0.1-pre", semver.Patch},
+	{"0.0.1", "0.0.1-pre-2", semver.Patch},
+	{"1.1.0", "1.1.0-pre", semver.Minor},
+	{"1.1.0-pre-1", "1.1.0-pre-2", semver.Prerelease},
+	{"1.0.0", "1.0.0", semver.None},
+	{"1.0.0-1", "1.0.0-1", semver.None},
+	{"0.0.2-1", "0.0.2", semver.Patch},
+	{"0.0.2-1", "0.0.3", semver.Patch},
+	{"0.0.2-1", "0.1.0", semver.Minor},
+	{"0.0.2-1", "1.0.0", semver.Major},
+	{"0.1.0-1", "0.1.0", semver.Minor},
+	{"1.0.0-1", "1.0.0", semver.Major},
+	{"1.0.0-1", "1.1.1", semver.Major},
+	{"1.0.0-1", "2.1.1", semver.Major},
+	{"1.0.1-1", "1.0.1", semver.Patch},
+	{"0.0.0-1", "0.0.0", semver.Major},
+	{"1.0.0-1", "2.0.0", semver.Major},
+	{"1.0.0-1", "2.0.0-1", semver.PreMajor},
+	{"1.0.0-1", "1.1.0-1", semver.PreMinor},
+	{"1.0.0-1", "1.0.1-1", semver.PrePatch},
+
+	// Metadata doesn't change the precedence, but is still a difference.
+	{"1.2.3+build.1", "1.2.3+build.2", semver.Metadata},
+	{"1.2.3-rc.1+build", "1.2.3-rc.1", semver.Metadata},
+	{"1.2.3+build", "1.2.4", semver.Patch},
+	{"1.18446744073709551616.0", "1.18446744073709551617.0", semver.Minor},
+}
+
+func TestDiff(t *testing.T) {
+	for _, c := range diffs {
+		v1, _ := semver.New(c.v1)
+		v2, _ := semver.New(c.v2)
+		if result := v1.Diff(v2); result != c.expected {
+			t.Errorf("%q.Diff(%q) => %v, want %v", v1, v2, result, c.expected)
+		}
+	}
+}
+
+func TestReleaseString(t *testing.T) {
+	if result := semver.PreMinor.String(); result != "preminor" {
+		t.Errorf("String() => %q, want %q", result, "preminor")
+	}
+}
@@ -3,12 +3,10 @@
 package semver
 
 import (
-	"bufio"
-	"bytes"
-	"errors"
-	"fmt"
+	"math"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 const (
@@ -19,233 +17,506 @@ const (
 )
 
 // Version is the container for semver version data.
+// Prerelease and metadata identifiers are kept in their dot separated string form,
+// so copies of a Version share no mutable state. Versions are comparable with ==,
+// which includes the metadata, and can be used as map keys.
+// A Version only changes through its pointer methods, such as SetMajor or UnmarshalText.
+// The With, Next and Bump methods return modified copies instead, so versions that are
+// not modified in place can be shared between goroutines.
+// Version numbers have no size limit. Numbers that don't fit in an uint64 are kept in
+// decimal form, while the uint64 getters report them as math.MaxUint64.
 type Version struct {
 	major      uint64
 	minor      uint64
 	patch      uint64
-	prerelease *prereleases
-	metadata   []string
-}
-
-type prereleases struct {
-	values  []string
-	numbers []int
+	large      string // major.minor.patch in decimal, only set if a number exceeds math.MaxUint64
+	prerelease string
+	metadata   string
 }
 
 // Build accepts version numbers in uint64 and optional prerelease and metadata information in a string array.
 // Build circumvents error checking, and is mostly used for testing.
 func Build(major, minor, patch uint64, extra ...[]string) *Version {
-	if len(extra) == 1 {
-		ver := &Version{major, minor, patch, nil, nil}
+	ver := &Version{major: major, minor: minor, patch: patch}
+	if len(extra) > 0 {
 		ver.SetPrerelease(extra[0]...)
-		return ver
 	}
 	if len(extra) > 1 {
-		ver := &Version{major, minor, patch, nil, extra[1]}
-		ver.SetPrerelease(extra[0]...)
-		return ver
+		ver.SetMetadata(extra[1]...)
 	}
-	return &Version{major, minor, patch, nil, nil}
+	return ver
+}
+
+// Clone returns a copy of v.
+func (v Version) Clone() *Version {
+	return &v
 }
 
 // New accepts a valid semver version string and returns a Version struct.
-// Returns error if the supplied string is an invalid semver version.
+// Returns a *ParseError if the supplied string is an invalid semver version.
 func New(version string) (*Version, error) {
-	var versions []string
-	var prereleases []string
-	var metadatas []string
+	result, err := parse(version)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
 
-	result := new(Version)
+// ParseBytes is like New, but accepts the version as a byte slice and returns a Version value.
+// It does not allocate unless the version has prerelease or metadata identifiers, which are copied once.
+func ParseBytes(version []byte) (Version, error) {
+	return parse(version)
+}
+
+// parse reads a version in a single pass over its input.
+func parse[T string | []byte](input T) (Version, error) {
+	var v Version
+	var versionNumbers [3]uint64
+	large := false
+	pos := 0
+
+	for i := range versionNumbers {
+		start := pos
+		for pos < len(input) && '0' <= input[pos] && input[pos] <= '9' {
+			digit := uint64(input[pos] - '0')
+			if versionNumbers[i] > (math.MaxUint64-digit)/10 {
+				large = true // kept in decimal form below
+			} else {
+				versionNumbers[i] = versionNumbers[i]*10 + digit
+			}
+			pos++
+		}
 
-	if strings.Contains(version, plus) {
-		metadata := strings.Split(version, plus)
-		metadatas = strings.Split(metadata[1], dot)
+		// Each number must be followed by a dot, except the patch version,
+		// which is followed by the prerelease, the metadata or the end of input.
+		switch {
+		case pos == start && (pos == len(input) || strings.IndexByte(delimiters, input[pos]) >= 0):
+			return v, &ParseError{string(input), pos, "", MissingComponent}
+		case pos == len(input) || input[pos] == '-' || input[pos] == '+':
+			if i < 2 {
+				return v, &ParseError{string(input), pos, "", MissingComponent}
+			}
+		case input[pos] != '.' || i == 2:
+			return v, invalidCharacter(string(input), pos)
+		}
 
-		if err := result.SetMetadata(metadatas...); err != nil {
-			return nil, err
+		if pos-start > 1 && input[start] == '0' {
+			return v, &ParseError{string(input), start, string(input[start:pos]), LeadingZero}
+		}
+		if i < 2 {
+			pos++
 		}
+	}
 
-		version = metadata[0]
+	if large {
+		v.setDecimals(strings.SplitN(string(input[:pos]), dot, 3))
+	} else {
+		v.major = versionNumbers[0]
+		v.minor = versionNumbers[1]
+		v.patch = versionNumbers[2]
 	}
 
-	if strings.Contains(version, hyphen) {
-		prerelease := strings.SplitN(version, hyphen, 2)
-		prereleases = strings.Split(prerelease[1], dot)
+	// Prerelease and metadata share a single copy of the rest of the input.
+	var extra string
+	if pos < len(input) {
+		extra = string(input[pos:])
+	}
+	offset := pos
 
-		if err := result.SetPrerelease(prereleases...); err != nil {
-			return nil, err
+	if pos < len(input) && input[pos] == '-' {
+		end, err := scanIdentifiers(input, pos+1, len(input), true)
+		if err != nil {
+			return v, err
 		}
+		v.prerelease = extra[pos+1-offset : end-offset]
+		pos = end
+	}
 
-		version = prerelease[0]
+	if pos < len(input) && input[pos] == '+' {
+		if _, err := scanIdentifiers(input, pos+1, len(input), false); err != nil {
+			return v, err
+		}
+		v.metadata = extra[pos+1-offset:]
 	}
+	return v, nil
+}
 
-	versions = strings.Split(version, dot)
-	if len(versions) != 3 {
-		return nil, errors.New("major.minor.patch pattern not found")
+// scanIdentifiers validates the dot separated identifiers in input[pos:end]. Prerelease identifiers
+// end at the plus introducing the metadata, and must not have leading zeroes when numerical.
+// Returns the position following the last identifier.
+func scanIdentifiers[T string | []byte](input T, pos, end int, prerelease bool) (int, error) {
+	start := pos
+	numerical := true
+
+	for ; ; pos++ {
+		if pos == end || input[pos] == '.' || (prerelease && input[pos] == '+') {
+			if pos == start {
+				return 0, &ParseError{string(input), pos, "", EmptyIdentifier}
+			}
+			if prerelease && numerical && pos-start > 1 && input[start] == '0' {
+				return 0, &ParseError{string(input), start, string(input[start:pos]), LeadingZero}
+			}
+			if pos == end || input[pos] == '+' {
+				return pos, nil
+			}
+			start = pos + 1
+			numerical = true
+			continue
+		}
+		if !alphanumeric(rune(input[pos])) {
+			return 0, invalidCharacter(string(input), pos)
+		}
+		if !numbers(rune(input[pos])) {
+			numerical = false
+		}
 	}
+}
 
-	var versionNumbers [3]uint64
-	for i, partial := range versions {
+// joinIdentifiers joins identifiers with dots, rejecting identifiers that contain a dot themselves.
+func joinIdentifiers(identifiers []string) (string, error) {
+	input := strings.Join(identifiers, dot)
+	offset := 0
+	for _, ident := range identifiers {
+		if i := strings.IndexByte(ident, '.'); i >= 0 {
+			return "", invalidCharacter(input, offset+i)
+		}
+		offset += len(ident) + 1
+	}
+	return input, nil
+}
 
-		num, err := strconv.ParseUint(partial, 10, 0)
+// invalidCharacter reports the rune found at pos in input.
+func invalidCharacter(input string, pos int) error {
+	_, width := utf8.DecodeRuneInString(input[pos:])
+	return &ParseError{input, pos, input[pos : pos+width], InvalidCharacter}
+}
+
+// checkNumber validates the major, minor or patch version found at offset in input.
+func checkNumber(input string, offset int, number string) error {
+	if len(number) < 1 {
+		return &ParseError{input, offset, number, MissingComponent}
+	}
+	if i := strings.IndexFunc(number, func(r rune) bool { return !numbers(r) }); i >= 0 {
+		return invalidCharacter(input, offset+i)
+	}
+	if hasLeadingZero(number) {
+		return &ParseError{input, offset, number, LeadingZero}
+	}
+	return nil
+}
+
+// number returns the major, minor or patch version for i 0, 1 or 2.
+func (v *Version) number(i int) *uint64 {
+	return [...]*uint64{&v.major, &v.minor, &v.patch}[i]
+}
+
+// decimals returns the major, minor and patch versions in decimal form.
+func (v *Version) decimals() []string {
+	if v.large != "" {
+		return strings.SplitN(v.large, dot, 3)
+	}
+	return []string{
+		strconv.FormatUint(v.major, 10),
+		strconv.FormatUint(v.minor, 10),
+		strconv.FormatUint(v.patch, 10),
+	}
+}
+
+// setDecimals sets the major, minor and patch versions from valid decimal numbers,
+// keeping their decimal form only if one of them doesn't fit in an uint64.
+func (v *Version) setDecimals(decimals []string) {
+	v.large = ""
+	for i, decimal := range decimals {
+		n, err := strconv.ParseUint(decimal, 10, 64)
 		if err != nil {
-			return nil, errors.New(fmt.Sprint("expected unsigned integer: ", partial))
-		}
-		if hasLeadingZero(partial) {
-			return nil, errors.New(fmt.Sprint("leading zeroes in version number: ", partial))
+			n = math.MaxUint64
+			v.large = strings.Join(decimals, dot)
 		}
-		versionNumbers[i] = num
+		*v.number(i) = n
+	}
+}
+
+// setNumber sets the major, minor or patch version for i 0, 1 or 2.
+func (v *Version) setNumber(i int, n uint64) {
+	if v.large == "" {
+		*v.number(i) = n
+		return
 	}
+	decimals := v.decimals()
+	decimals[i] = strconv.FormatUint(n, 10)
+	v.setDecimals(decimals)
+}
 
-	result.major = versionNumbers[0]
-	result.minor = versionNumbers[1]
-	result.patch = versionNumbers[2]
+// increment adds 1 to the major, minor or patch version for i 0, 1 or 2, going beyond math.MaxUint64 if need be.
+func (v *Version) increment(i int) {
+	if n := v.number(i); v.large == "" && *n < math.MaxUint64 {
+		*n++
+		return
+	}
+	decimals := v.decimals()
+	decimals[i] = incrementNumber(decimals[i])
+	v.setDecimals(decimals)
+}
 
-	return result, nil
+// decrement subtracts 1 from the major, minor or patch version for i 0, 1 or 2,
+// or returns ErrUnderflow and leaves v unchanged if it is 0.
+func (v *Version) decrement(i int) error {
+	if n := v.number(i); v.large == "" {
+		if *n == 0 {
+			return ErrUnderflow
+		}
+		*n--
+		return nil
+	}
+	decimals := v.decimals()
+	if decimals[i] == "0" {
+		return ErrUnderflow
+	}
+	decimals[i] = decrementNumber(decimals[i])
+	v.setDecimals(decimals)
+	return nil
 }
 
 // String returns a valid semver string based on the data contained in Version.
 func (v Version) String() string {
-	var buffer bytes.Buffer
-	w := bufio.NewWriter(&buffer)
+	return string(v.appendString(make([]byte, 0, 3*4+len(v.large)+len(v.prerelease)+len(v.metadata)+2)))
+}
 
-	fmt.Fprintf(w, "%d.%d.%d", v.major, v.minor, v.patch)
+// appendString appends the semver string of v to b.
+func (v Version) appendString(b []byte) []byte {
+	if v.large != "" {
+		b = append(b, v.large...)
+	} else {
+		b = strconv.AppendUint(b, v.major, 10)
+		b = append(b, dot...)
+		b = strconv.AppendUint(b, v.minor, 10)
+		b = append(b, dot...)
+		b = strconv.AppendUint(b, v.patch, 10)
+	}
 
-	if v.prerelease != nil {
-		fmt.Fprintf(w, "%v%v", hyphen, v.Prerelease())
+	if v.prerelease != "" {
+		b = append(b, hyphen...)
+		b = append(b, v.prerelease...)
 	}
 
-	if v.metadata != nil {
-		fmt.Fprintf(w, "%v%v", plus, v.Metadata())
+	if v.metadata != "" {
+		b = append(b, plus...)
+		b = append(b, v.metadata...)
 	}
 
-	w.Flush()
-	return buffer.String()
+	return b
 }
 
-// Major returns the major version.
+// Major returns the major version, or math.MaxUint64 if it is larger than that.
 func (v Version) Major() uint64 {
 	return v.major
 }
 
 // SetMajor accepts a uint64 to change the currently set major version.
+// SetMajor modifies v in place, use WithMajor to get a modified copy instead.
 func (v *Version) SetMajor(major uint64) {
-	v.major = major
+	v.setNumber(0, major)
+}
+
+// WithMajor returns a copy of v with the major version set to major.
+func (v Version) WithMajor(major uint64) *Version {
+	v.setNumber(0, major)
+	return &v
+}
+
+// NextMajor returns the major release following v, as given by Bump(Major, "").
+func (v Version) NextMajor() *Version {
+	next, _ := v.Bump(Major, "")
+	return next
 }
 
-// IncrementMajor increases Major version by 1.
+// IncrementMajor increases Major version by 1, in place.
 func (v *Version) IncrementMajor() {
-	v.major++
+	v.increment(0)
 }
 
-// DecrementMajor decreases Major version by 1.
-func (v *Version) DecrementMajor() {
-	v.major--
+// DecrementMajor decreases Major version by 1, in place.
+// Returns ErrUnderflow and leaves v unchanged if Major is 0.
+func (v *Version) DecrementMajor() error {
+	return v.decrement(0)
 }
 
-// Minor returns the minor version.
+// Minor returns the minor version, or math.MaxUint64 if it is larger than that.
 func (v Version) Minor() uint64 {
 	return v.minor
 }
 
 // SetMinor accepts a uint64 to change the currently set minor version.
+// SetMinor modifies v in place, use WithMinor to get a modified copy instead.
 func (v *Version) SetMinor(minor uint64) {
-	v.minor = minor
+	v.setNumber(1, minor)
 }
 
-// IncrementMinor increases Minor version by 1.
+// WithMinor returns a copy of v with the minor version set to minor.
+func (v Version) WithMinor(minor uint64) *Version {
+	v.setNumber(1, minor)
+	return &v
+}
+
+// NextMinor returns the minor release following v, as given by Bump(Minor, "").
+func (v Version) NextMinor() *Version {
+	next, _ := v.Bump(Minor, "")
+	return next
+}
+
+// IncrementMinor increases Minor version by 1, in place.
 func (v *Version) IncrementMinor() {
-	v.minor++
+	v.increment(1)
 }
 
-// DecrementMinor decreases Minor version by 1.
-func (v *Version) DecrementMinor() {
-	v.minor--
+// DecrementMinor decreases Minor version by 1, in place.
+// Returns ErrUnderflow and leaves v unchanged if Minor is 0.
+func (v *Version) DecrementMinor() error {
+	return v.decrement(1)
 }
 
-// Patch returns the patch version.
+// Patch returns the patch version, or math.MaxUint64 if it is larger than that.
 func (v Version) Patch() uint64 {
 	return v.patch
 }
 
 // SetPatch accepts a uint64 to change the currently set patch version.
+// SetPatch modifies v in place, use WithPatch to get a modified copy instead.
 func (v *Version) SetPatch(patch uint64) {
-	v.patch = patch
+	v.setNumber(2, patch)
 }
 
-// IncrementPatch increases Patch version by 1.
+// WithPatch returns a copy of v with the patch version set to patch.
+func (v Version) WithPatch(patch uint64) *Version {
+	v.setNumber(2, patch)
+	return &v
+}
+
+// NextPatch returns the patch release following v, as given by Bump(Patch, "").
+func (v Version) NextPatch() *Version {
+	next, _ := v.Bump(Patch, "")
+	return next
+}
+
+// IncrementPatch increases Patch version by 1, in place.
 func (v *Version) IncrementPatch() {
-	v.patch++
+	v.increment(2)
 }
 
-// DecrementPatch decreases Patch version by 1.
-func (v *Version) DecrementPatch() {
-	v.patch--
+// DecrementPatch decreases Patch version by 1, in place.
+// Returns ErrUnderflow and leaves v unchanged if Patch is 0.
+func (v *Version) DecrementPatch() error {
+	return v.decrement(2)
 }
 
 // Prerelease returns the prerelease identifiers as a dot seperated string.
 func (v Version) Prerelease() string {
-	return strings.Join(v.prerelease.values, dot)
+	return v.prerelease
 }
 
 // SetPrerelease accepts a series of strings to form the prerelease identifiers.
-// Returns error if any of the supplied strings aren't a valid prerelease identifier.
+// SetPrerelease modifies v in place, use WithPrerelease to get a modified copy instead.
+// Returns a *ParseError if any of the supplied strings aren't a valid prerelease identifier.
 func (v *Version) SetPrerelease(identifiers ...string) error {
-	var result []string
-	var numbers []int
-
-	for _, ident := range identifiers {
-		if len(ident) < 1 {
-			return errors.New("identifier is empty")
+	input, err := joinIdentifiers(identifiers)
+	if err != nil {
+		return err
+	}
+	if len(identifiers) > 0 {
+		end, err := scanIdentifiers(input, 0, len(input), true)
+		if err != nil {
+			return err
 		}
-
-		if num, err := strconv.ParseUint(ident, 10, 0); err == nil {
-			if hasLeadingZero(ident) {
-				return errors.New(fmt.Sprint("leading zeroes in numerical identifier: ", ident))
-			}
-			numbers = append(numbers, int(num))
-			result = append(result, ident)
-		} else {
-			if !containsOnly(ident, alphanumeric) {
-				return errors.New(fmt.Sprint("not alphanumerical: ", ident))
-			}
-			numbers = append(numbers, -1)
-			result = append(result, ident)
+		if end < len(input) {
+			return invalidCharacter(input, end)
 		}
 	}
-	pre := &prereleases{result, numbers}
-	v.prerelease = pre
+	v.prerelease = input
 	return nil
 }
 
+// WithPrerelease returns a copy of v with the prerelease identifiers set to identifiers.
+// Returns a *ParseError if any of the supplied strings aren't a valid prerelease identifier.
+func (v Version) WithPrerelease(identifiers ...string) (*Version, error) {
+	if err := v.SetPrerelease(identifiers...); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (v *Version) isPrerelease() bool {
+	return v.prerelease != ""
+}
+
+// minVersion is the lowest version there is.
+var minVersion = &Version{prerelease: "0"}
+
+// release returns the major.minor.patch of v, without its prerelease and metadata.
+func (v *Version) release() Version {
+	return Version{major: v.major, minor: v.minor, patch: v.patch, large: v.large}
+}
+
+// successor returns the lowest version above v, ignoring metadata.
+// That is 1.2.4-0 for 1.2.3, and 1.2.3-rc.0 for 1.2.3-rc.
+func (v *Version) successor() *Version {
+	next := *v
+	next.metadata = ""
+	if next.isPrerelease() {
+		next.prerelease += dot + "0"
+	} else {
+		next.advance(2)
+		next.prerelease = "0"
+	}
+	return &next
+}
+
 // Metadata returns the metadata identifiers as a dot seperated string.
 func (v Version) Metadata() string {
-	return strings.Join(v.metadata, dot)
+	return v.metadata
 }
 
 // SetMetadata accepts a series of strings to form the metadata identifiers.
-// Returns error if any of the supplied strings aren't a valid metadata identifier.
+// SetMetadata modifies v in place, use WithMetadata to get a modified copy instead.
+// Returns a *ParseError if any of the supplied strings aren't a valid metadata identifier.
 func (v *Version) SetMetadata(identifiers ...string) error {
-	var result []string
-
-	for _, ident := range identifiers {
-		if len(ident) < 1 {
-			return errors.New("identifier is empty")
-		}
-
-		if !containsOnly(ident, alphanumeric) {
-			return errors.New(fmt.Sprint("not alphanumerical: ", ident))
+	input, err := joinIdentifiers(identifiers)
+	if err != nil {
+		return err
+	}
+	if len(identifiers) > 0 {
+		if _, err := scanIdentifiers(input, 0, len(input), false); err != nil {
+			return err
 		}
-		result = append(result, ident)
 	}
-
-	v.metadata = result
+	v.metadata = input
 	return nil
 }
 
-// Satifies accepts a set of comparators and version numbers as a string.
+// WithMetadata returns a copy of v with the metadata identifiers set to identifiers.
+// Returns a *ParseError if any of the supplied strings aren't a valid metadata identifier.
+func (v Version) WithMetadata(identifiers ...string) (*Version, error) {
+	if err := v.SetMetadata(identifiers...); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// Satisfies accepts a set of comparators and version numbers as a string.
 // The syntax for the string is documented here: https://www.npmjs.org/doc/misc/semver.html
 // Returns true if Version matches the comparators, false if it does not.
-func (v Version) Satifies(requirements string) (bool, error) {
-	return false, nil
+// Options alter how the requirements match, see Option.
+// Returns error if the requirements string could not be parsed.
+func (v Version) Satisfies(requirements string, options ...Option) (bool, error) {
+	r, err := ParseRange(requirements, options...)
+	if err != nil {
+		return false, err
+	}
+	return r.Check(&v), nil
+}
+
+// Satifies is a misspelled alias of Satisfies.
+//
+// Deprecated: use Satisfies instead.
+func (v Version) Satifies(requirements string, options ...Option) (bool, error) {
+	return v.Satisfies(requirements, options...)
 }
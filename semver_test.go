@@ -1,8 +1,14 @@
 package semver_test
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"sync"
 	"testing"
+	"testing/quick"
 
 	"github.com/hansrodtang/semver"
 )
@@ -95,6 +101,48 @@ var comparisons = []comparison{
 	{semver.Build(1, 0, 0, []string{"beta", "alpha", "1"}), semver.Build(1, 0, 0, []string{"beta", "alpha"}), 1},
 
 	{semver.Build(1, 0, 0, []string{"rc", "1"}), semver.Build(1, 0, 0, []string{"rc", "1"}, []string{"435345345"}), 0},
+
+	// Numerical identifiers
+	{semver.Build(1, 0, 0, []string{"0"}), semver.Build(1, 0, 0, []string{"1"}), -1},
+	{semver.Build(1, 0, 0, []string{"2"}), semver.Build(1, 0, 0, []string{"1a"}), -1},
+	{semver.Build(1, 0, 0, []string{"9"}), semver.Build(1, 0, 0, []string{"-"}), -1},
+	{semver.Build(1, 0, 0, []string{"99999999999999999999"}), semver.Build(1, 0, 0, []string{"100000000000000000000"}), -1},
+}
+
+type satisfaction struct {
+	version      string
+	requirements string
+	expected     bool
+}
+
+var satisfactions = []satisfaction{
+	{"1.2.7", "1.2.7 || >=1.2.9 <2.0.0", true},
+	{"1.2.8", "1.2.7 || >=1.2.9 <2.0.0", false},
+	{"1.4.6", "1.2.7 || >=1.2.9 <2.0.0", true},
+	{"2.0.0", "1.2.7 || >=1.2.9 <2.0.0", false},
+	{"1.9.7", "1.0.0 - 2.0.0", true},
+	{"1.2.5", "~1.2.3", true},
+	{"1.3.0", "~1.2.3", false},
+	{"1.5.0", "1.x", true},
+	{"1.5.0", "^1.0.0", true},
+	{"0.2.5", "^0.1.0", false},
+	{"2.0.0-alpha", ">=1.0.0", false},
+	{"1.2.3-beta.4", "^1.2.3-beta.2", true},
+	{"18446744073709551616.0.0", "^18446744073709551615.0.0", false},
+	{"18446744073709551615.99999999999999999999.0", "^18446744073709551615.0.0", true},
+	{"1.99999999999999999999.0-rc.1", "~1.99999999999999999999.0-rc.0", true},
+	{"1.2.3", "", true},
+	{"1.2.3", " ", true},
+	{"1.2.3-rc", "", false},
+}
+
+var badRequirements = []string{
+	"~ 1.2.3",
+	">= 1.2.3",
+	"01.2.3",
+	"1.2.3 - 1.x",
+	"1.2.3 || 01.0.0",
+	"5.3.5 |1| 4.3.5",
 }
 
 var badPreRelease = [][]string{
@@ -187,6 +235,237 @@ func TestDecrementers(t *testing.T) {
 	}
 }
 
+func TestWith(t *testing.T) {
+	ver, _ := semver.New("1.2.3-alpha.1+35.45")
+	expected := ver.String()
+
+	with := []struct {
+		result   *semver.Version
+		expected string
+	}{
+		{ver.WithMajor(4), "4.2.3-alpha.1+35.45"},
+		{ver.WithMinor(4), "1.4.3-alpha.1+35.45"},
+		{ver.WithPatch(4), "1.2.4-alpha.1+35.45"},
+		{ver.Clone(), "1.2.3-alpha.1+35.45"},
+		{ver.NextMajor(), "2.0.0"},
+		{ver.NextMinor(), "1.3.0"},
+		{ver.NextPatch(), "1.2.3"},
+	}
+	for _, w := range with {
+		if result := w.result.String(); result != w.expected {
+			t.Errorf("With() => %q, want %q", result, w.expected)
+		}
+	}
+
+	pre, err := ver.WithPrerelease("beta")
+	if err != nil || pre.String() != "1.2.3-beta+35.45" {
+		t.Errorf("WithPrerelease(%q) => %q, %v, want %q", "beta", pre, err, "1.2.3-beta+35.45")
+	}
+	meta, err := ver.WithMetadata()
+	if err != nil || meta.String() != "1.2.3-alpha.1" {
+		t.Errorf("WithMetadata() => %q, %v, want %q", meta, err, "1.2.3-alpha.1")
+	}
+	if _, err := ver.WithPrerelease("01"); err == nil {
+		t.Errorf("WithPrerelease(%q) => <nil>, want Error", "01")
+	}
+
+	if result := ver.String(); result != expected {
+		t.Errorf("With() changed the receiver to %q, want %q", result, expected)
+	}
+}
+
+func TestOverflow(t *testing.T) {
+	// Version numbers have no size limit, so incrementing goes beyond math.MaxUint64 and decrementing comes back.
+	max := semver.Build(math.MaxUint64, math.MaxUint64, math.MaxUint64)
+	increments := []struct {
+		name     string
+		f        func()
+		expected string
+	}{
+		{"IncrementMajor", max.IncrementMajor, "18446744073709551616.18446744073709551615.18446744073709551615"},
+		{"IncrementMinor", max.IncrementMinor, "18446744073709551616.18446744073709551616.18446744073709551615"},
+		{"IncrementPatch", max.IncrementPatch, "18446744073709551616.18446744073709551616.18446744073709551616"},
+	}
+	for _, s := range increments {
+		if s.f(); max.String() != s.expected {
+			t.Errorf("%v() => %q, want %q", s.name, max, s.expected)
+		}
+	}
+	decrements := []struct {
+		name     string
+		f        func() error
+		expected string
+	}{
+		{"DecrementMajor", max.DecrementMajor, "18446744073709551615.18446744073709551616.18446744073709551616"},
+		{"DecrementMinor", max.DecrementMinor, "18446744073709551615.18446744073709551615.18446744073709551616"},
+		{"DecrementPatch", max.DecrementPatch, "18446744073709551615.18446744073709551615.18446744073709551615"},
+	}
+	for _, s := range decrements {
+		if err := s.f(); err != nil || max.String() != s.expected {
+			t.Errorf("%v() => %q, %v, want %q, <nil>", s.name, max, err, s.expected)
+		}
+	}
+	if !max.StrictEqual(semver.Build(math.MaxUint64, math.MaxUint64, math.MaxUint64)) {
+		t.Errorf("%q isn't the same as before incrementing", max)
+	}
+
+	zero := semver.Build(0, 0, 0)
+	decrementers := map[string]func() error{
+		"DecrementMajor": zero.DecrementMajor,
+		"DecrementMinor": zero.DecrementMinor,
+		"DecrementPatch": zero.DecrementPatch,
+	}
+	for name, f := range decrementers {
+		if err := f(); !errors.Is(err, semver.ErrUnderflow) {
+			t.Errorf("%v() => %v, want %v", name, err, semver.ErrUnderflow)
+		}
+	}
+
+	if zero.String() != "0.0.0" {
+		t.Errorf("failed arithmetic changed the version to %q", zero)
+	}
+}
+
+func TestLargeNumbers(t *testing.T) {
+	ordered := []string{
+		"1.18446744073709551615.0",
+		"1.18446744073709551615.18446744073709551616",
+		"1.18446744073709551616.0-rc.1",
+		"1.18446744073709551616.0",
+		"1.99999999999999999999.0",
+		"1.100000000000000000000.0",
+		"2.0.0",
+		"18446744073709551616.0.0",
+	}
+	for i := range ordered {
+		for j := range ordered {
+			v1, err1 := semver.New(ordered[i])
+			v2, err2 := semver.New(ordered[j])
+			if err1 != nil || err2 != nil {
+				t.Fatalf("New(%q), New(%q) => %v, %v, want <nil>", ordered[i], ordered[j], err1, err2)
+			}
+			want := 0
+			if i < j {
+				want = -1
+			} else if i > j {
+				want = 1
+			}
+			if result := v1.Compare(v2); result != want {
+				t.Errorf("%q.Compare(%q) => %d, want %d", v1, v2, result, want)
+			}
+			if (*v1 == *v2) != (i == j) {
+				t.Errorf("%q == %q => %t, want %t", v1, v2, *v1 == *v2, i == j)
+			}
+		}
+	}
+
+	ver, _ := semver.New("99999999999999999999.2.3-rc.1")
+	if ver.Major() != math.MaxUint64 || ver.Minor() != 2 || ver.Patch() != 3 {
+		t.Errorf("%q getters => %d, %d, %d", ver, ver.Major(), ver.Minor(), ver.Patch())
+	}
+	bumps := []struct {
+		result   *semver.Version
+		expected string
+	}{
+		{ver.NextMajor(), "100000000000000000000.0.0"},
+		{ver.NextMinor(), "99999999999999999999.3.0"},
+		{ver.NextPatch(), "99999999999999999999.2.3"},
+		{ver.WithMinor(5), "99999999999999999999.5.3-rc.1"},
+		{semver.Build(math.MaxUint64, 1, 0).NextMajor(), "18446744073709551616.0.0"},
+	}
+	for _, b := range bumps {
+		if result := b.result.String(); result != b.expected {
+			t.Errorf("Next() => %q, want %q", result, b.expected)
+		}
+	}
+	if small := ver.WithMajor(1); *small != *semver.Build(1, 2, 3, []string{"rc", "1"}) {
+		t.Errorf("WithMajor(1) => %q, want the same as %q", small, "1.2.3-rc.1")
+	}
+
+	if ver.IncrementMajor(); ver.String() != "100000000000000000000.2.3-rc.1" {
+		t.Errorf("IncrementMajor() => %q, want %q", ver, "100000000000000000000.2.3-rc.1")
+	}
+	if err := ver.DecrementMajor(); err != nil || ver.String() != "99999999999999999999.2.3-rc.1" {
+		t.Errorf("DecrementMajor() => %q, %v, want %q, <nil>", ver, err, "99999999999999999999.2.3-rc.1")
+	}
+	if ver.IncrementPatch(); ver.String() != "99999999999999999999.2.4-rc.1" {
+		t.Errorf("IncrementPatch() => %q, want %q", ver, "99999999999999999999.2.4-rc.1")
+	}
+}
+
+func TestIncrementDecrement(t *testing.T) {
+	roundtrip := func(major, minor, patch uint64) bool {
+		v := semver.Build(major, minor, patch)
+		v.IncrementMinor()
+		return v.DecrementMinor() == nil && v.StrictEqual(semver.Build(major, minor, patch))
+	}
+	if err := quick.Check(roundtrip, nil); err != nil {
+		t.Error(err)
+	}
+	if !roundtrip(0, math.MaxUint64, 0) || !roundtrip(0, math.MaxUint64-1, 0) {
+		t.Errorf("IncrementMinor() at the boundary doesn't roundtrip")
+	}
+}
+
+func TestNumericPrerelease(t *testing.T) {
+	// Numeric prerelease identifiers have no size limit, so bumping and comparing
+	// them has to agree with arbitrary precision arithmetic.
+	bump := func(n uint64, digits uint8) bool {
+		x := new(big.Int).SetUint64(n)
+		x.Mul(x, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits%40)), nil))
+		v := semver.Build(1, 2, 3, []string{"rc", x.String()})
+		bumped, err := v.Bump(semver.Prerelease, "")
+		return err == nil && bumped.String() == "1.2.3-rc."+x.Add(x, big.NewInt(1)).String()
+	}
+	if err := quick.Check(bump, nil); err != nil {
+		t.Error(err)
+	}
+
+	compare := func(a, b uint64, digits uint8) bool {
+		scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits%40)), nil)
+		x := new(big.Int).Mul(new(big.Int).SetUint64(a), scale)
+		y := new(big.Int).Mul(new(big.Int).SetUint64(b), scale)
+		v1 := semver.Build(1, 2, 3, []string{x.String()})
+		v2 := semver.Build(1, 2, 3, []string{y.String()})
+		return v1.Compare(v2) == x.Cmp(y)
+	}
+	if err := quick.Check(compare, nil); err != nil {
+		t.Error(err)
+	}
+	if !bump(math.MaxUint64, 0) || !compare(math.MaxUint64, math.MaxUint64-1, 1) {
+		t.Errorf("numeric prerelease identifiers overflow at the uint64 boundary")
+	}
+}
+
+func TestCloneIndependent(t *testing.T) {
+	ver, _ := semver.New("1.2.3-alpha.1+35.45")
+	clone := ver.Clone()
+	clone.SetPrerelease("beta")
+	clone.SetMetadata("1")
+	clone.IncrementMajor()
+
+	if ver.String() != "1.2.3-alpha.1+35.45" || clone.String() != "2.2.3-beta+1" {
+		t.Errorf("Clone() => %q shares state with %q", clone, ver)
+	}
+}
+
+func TestConcurrentWith(t *testing.T) {
+	ver, _ := semver.New("1.2.3-alpha.1+35.45")
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			next := ver.WithMinor(uint64(i))
+			if pre, err := next.WithPrerelease("beta", strconv.Itoa(i)); err != nil || pre.Minor() != uint64(i) {
+				t.Errorf("WithPrerelease(%q, %d) => %q, %v", "beta", i, pre, err)
+			}
+			ver.Compare(next)
+		}(i)
+	}
+	wg.Wait()
+}
+
 func TestNew(t *testing.T) {
 	expected := "1.0.3-alpha.1+35.45"
 
@@ -205,9 +484,8 @@ func TestNew(t *testing.T) {
 func TestBadFormat(t *testing.T) {
 	for _, version := range badVersions {
 		_, err := semver.New(version)
-		if err == nil {
-			// TODO: Set up error types
-			t.Errorf("New(%q) => %v, want Error", version, err)
+		if _, ok := err.(*semver.ParseError); !ok {
+			t.Errorf("New(%q) => %v, want *ParseError", version, err)
 		}
 
 	}
@@ -222,6 +500,30 @@ func TestCorrectFormat(t *testing.T) {
 	}
 }
 
+func TestParseBytes(t *testing.T) {
+	for _, version := range correctVersions {
+		ver, err := semver.ParseBytes([]byte(version))
+		if err != nil {
+			t.Errorf("ParseBytes(%q) => %v, want <nil>", version, err)
+		}
+		if result := ver.String(); result != version {
+			t.Errorf("ParseBytes(%q) => %q, want %q", version, result, version)
+		}
+	}
+	for _, version := range badVersions {
+		if _, err := semver.ParseBytes([]byte(version)); err == nil {
+			t.Errorf("ParseBytes(%q) => <nil>, want Error", version)
+		}
+	}
+}
+
+func TestParseBytesAllocs(t *testing.T) {
+	version := []byte("1.22.333")
+	if allocs := testing.AllocsPerRun(100, func() { semver.ParseBytes(version) }); allocs != 0 {
+		t.Errorf("ParseBytes(%q) => %v allocs, want 0", version, allocs)
+	}
+}
+
 func TestComparison(t *testing.T) {
 	for _, c := range comparisons {
 		result := c.main.Compare(c.other)
@@ -231,6 +533,123 @@ func TestComparison(t *testing.T) {
 	}
 }
 
+var prereleaseOrder = []string{
+	"1.0.0-0",
+	"1.0.0-0.0",
+	"1.0.0-1",
+	"1.0.0-1.a",
+	"1.0.0-2",
+	"1.0.0-10",
+	"1.0.0-10.1",
+	"1.0.0-11",
+	"1.0.0-19",
+	"1.0.0-1a",
+	"1.0.0-1a.1",
+	"1.0.0-1b",
+	"1.0.0-a",
+	"1.0.0-a.1",
+	"1.0.0-a.1.1",
+	"1.0.0-a.2",
+	"1.0.0-a.10",
+	"1.0.0-a.a",
+	"1.0.0-a-b",
+	"1.0.0-a1",
+	"1.0.0-a10",
+	"1.0.0-a2",
+	"1.0.0-b",
+	"1.0.0",
+}
+
+func TestComparePrerelease(t *testing.T) {
+	for i := range prereleaseOrder {
+		for j := range prereleaseOrder {
+			v1, _ := semver.New(prereleaseOrder[i])
+			v2, _ := semver.New(prereleaseOrder[j])
+			want := 0
+			if i < j {
+				want = -1
+			} else if i > j {
+				want = 1
+			}
+			if result := v1.Compare(v2); result != want {
+				t.Errorf("%q.Compare(%q) => %d, want %d", v1, v2, result, want)
+			}
+		}
+	}
+}
+
+var buildOrder = []string{
+	"1.2.3-rc.1",
+	"1.2.3-rc.1+build",
+	"1.2.3",
+	"1.2.3+2",
+	"1.2.3+010",
+	"1.2.3+10",
+	"1.2.3+10.1",
+	"1.2.3+build",
+	"1.2.3+build.1",
+	"1.2.4+1",
+}
+
+func TestCompareBuild(t *testing.T) {
+	for i := range buildOrder {
+		for j := range buildOrder {
+			v1, _ := semver.New(buildOrder[i])
+			v2, _ := semver.New(buildOrder[j])
+			want := 0
+			if i < j {
+				want = -1
+			} else if i > j {
+				want = 1
+			}
+			if result := v1.CompareBuild(v2); result != want {
+				t.Errorf("%q.CompareBuild(%q) => %d, want %d", v1, v2, result, want)
+			}
+			if result := v1.StrictEqual(v2); result != (i == j) {
+				t.Errorf("%q.StrictEqual(%q) => %t, want %t", v1, v2, result, i == j)
+			}
+			if result := v1.Equal(v2); result != (v1.Compare(v2) == 0) {
+				t.Errorf("%q.Equal(%q) => %t, want %t", v1, v2, result, !result)
+			}
+		}
+	}
+
+	v1, _ := semver.New("1.2.3+a")
+	v2, _ := semver.New("1.2.3+b")
+	if !v1.Equal(v2) || v1.StrictEqual(v2) {
+		t.Errorf("%q.Equal(%q), StrictEqual => %t, %t, want true, false", v1, v2, v1.Equal(v2), v1.StrictEqual(v2))
+	}
+}
+
+func TestSatisfies(t *testing.T) {
+	for _, c := range satisfactions {
+		ver, _ := semver.New(c.version)
+		result, err := ver.Satisfies(c.requirements)
+		if err != nil {
+			t.Errorf("%q.Satisfies(%q) => %v, want <nil>", ver, c.requirements, err)
+		}
+		if result != c.expected {
+			t.Errorf("%q.Satisfies(%q) => %t, want %t", ver, c.requirements, result, c.expected)
+		}
+	}
+}
+
+func TestSatisfiesIncludePrerelease(t *testing.T) {
+	ver, _ := semver.New("2.0.0-alpha")
+	if result, _ := ver.Satisfies(">=1.0.0", semver.IncludePrerelease); !result {
+		t.Errorf("%q.Satisfies(%q, IncludePrerelease) => %t, want %t", ver, ">=1.0.0", result, true)
+	}
+}
+
+func TestSatisfiesError(t *testing.T) {
+	ver := semver.Build(1, 2, 3)
+	for _, r := range badRequirements {
+		if result, err := ver.Satisfies(r); err == nil {
+			t.Errorf("%q.Satisfies(%q) => %t, want Error", ver, r, result)
+		}
+	}
+}
+
 func TestSetPrerelease(t *testing.T) {
 	ver := semver.Build(1, 2, 3)
 	err := ver.SetPrerelease("12", "1", "0", "43")
@@ -259,6 +678,26 @@ func BenchmarkParseComplex(b *testing.B) {
 	}
 }
 
+func BenchmarkParseBytesSimple(b *testing.B) {
+	VERSION := []byte("0.0.1")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		semver.ParseBytes(VERSION)
+	}
+}
+
+func BenchmarkParseBytesComplex(b *testing.B) {
+	VERSION := []byte("0.0.1-alpha.preview+123.456")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		semver.ParseBytes(VERSION)
+	}
+}
+
 func BenchmarkParseAverage(b *testing.B) {
 	l := len(correctVersions)
 
@@ -303,7 +742,7 @@ func BenchmarkCompareAverage(b *testing.B) {
 	}
 }
 
-func ExampleCompare() {
+func ExampleVersion_Compare() {
 	v1, _ := semver.New("1.6.0")
 	v2, _ := semver.New("1.5.0")
 	// do something with error
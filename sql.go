@@ -0,0 +1,105 @@
+package semver
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Scan implements the sql.Scanner interface, accepting versions stored as string or []byte.
+// Use NullVersion for columns that may be NULL.
+func (v *Version) Scan(src interface{}) error {
+	var ver Version
+	var err error
+	switch src := src.(type) {
+	case string:
+		ver, err = parse(src)
+	case []byte:
+		ver, err = parse(src)
+	default:
+		return fmt.Errorf("cannot scan %T into Version", src)
+	}
+	if err != nil {
+		return err
+	}
+	*v = ver
+	return nil
+}
+
+// Value implements the driver.Valuer interface, storing the version as a string.
+func (v Version) Value() (driver.Value, error) {
+	return v.String(), nil
+}
+
+// NullVersion is a Version that may be NULL. It implements the sql.Scanner and driver.Valuer interfaces.
+type NullVersion struct {
+	Version Version
+	Valid   bool // Valid is true if Version is not NULL
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *NullVersion) Scan(src interface{}) error {
+	if src == nil {
+		n.Version, n.Valid = Version{}, false
+		return nil
+	}
+	n.Valid = true
+	return n.Version.Scan(src)
+}
+
+// Value implements the driver.Valuer interface.
+func (n NullVersion) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Version.Value()
+}
+
+// Scan implements the sql.Scanner interface, accepting ranges stored as string or []byte.
+// Use NullRange for columns that may be NULL.
+func (r *Range) Scan(src interface{}) error {
+	var input string
+	switch src := src.(type) {
+	case string:
+		input = src
+	case []byte:
+		input = string(src)
+	default:
+		return fmt.Errorf("cannot scan %T into Range", src)
+	}
+	n, err := parseRange(input, 0)
+	if err != nil {
+		return err
+	}
+	r.root = n
+	return nil
+}
+
+// Value implements the driver.Valuer interface, storing the range as written by Sugared.
+// Options given to ParseRange are not part of the stored value.
+func (r Range) Value() (driver.Value, error) {
+	return r.Sugared(), nil
+}
+
+// NullRange is a Range that may be NULL. It implements the sql.Scanner and driver.Valuer interfaces.
+type NullRange struct {
+	Range Range
+	Valid bool // Valid is true if Range is not NULL
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *NullRange) Scan(src interface{}) error {
+	if src == nil {
+		n.Range, n.Valid = Range{}, false
+		return nil
+	}
+	n.Valid = true
+	return n.Range.Scan(src)
+}
+
+// Value implements the driver.Valuer interface.
+func (n NullRange) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Range.Value()
+}
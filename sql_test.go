@@ -0,0 +1,157 @@
+package semver_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/hansrodtang/semver"
+)
+
+// echoDriver is a fake database/sql driver. Every query returns a single row
+// holding the query arguments, so values make a full round trip through database/sql.
+type echoDriver struct{}
+
+type echoConn struct{}
+
+type echoStmt struct{}
+
+type echoRows struct {
+	row  []driver.Value
+	done bool
+}
+
+func (echoDriver) Open(name string) (driver.Conn, error) { return echoConn{}, nil }
+
+func (echoConn) Prepare(query string) (driver.Stmt, error) { return echoStmt{}, nil }
+func (echoConn) Close() error                              { return nil }
+func (echoConn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+
+func (echoStmt) Close() error  { return nil }
+func (echoStmt) NumInput() int { return -1 }
+func (echoStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+func (echoStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &echoRows{row: args}, nil
+}
+
+func (r *echoRows) Columns() []string { return make([]string, len(r.row)) }
+func (r *echoRows) Close() error      { return nil }
+func (r *echoRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	copy(dest, r.row)
+	r.done = true
+	return nil
+}
+
+func init() {
+	sql.Register("semver-echo", echoDriver{})
+}
+
+func echo(t *testing.T, arg interface{}, dest interface{}) error {
+	db, err := sql.Open("semver-echo", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	return db.QueryRow("SELECT ?", arg).Scan(dest)
+}
+
+func TestVersionSQL(t *testing.T) {
+	for _, version := range correctVersions {
+		ver, _ := semver.New(version)
+
+		var result semver.Version
+		if err := echo(t, ver, &result); err != nil {
+			t.Errorf("Scan(Value(%q)) => %v, want <nil>", ver, err)
+		}
+		if result.String() != version {
+			t.Errorf("Scan(Value(%q)) => %q, want %q", ver, result, version)
+		}
+
+		result = semver.Version{}
+		if err := echo(t, []byte(version), &result); err != nil || result.String() != version {
+			t.Errorf("Scan([]byte(%q)) => %q, %v, want %q, <nil>", version, result, err, version)
+		}
+	}
+
+	var result semver.Version
+	if err := echo(t, "1.2", &result); err == nil {
+		t.Errorf("Scan(%q) => <nil>, want Error", "1.2")
+	}
+	if err := echo(t, int64(1), &result); err == nil {
+		t.Errorf("Scan(%d) => <nil>, want Error", 1)
+	}
+	if err := echo(t, nil, &result); err == nil {
+		t.Errorf("Scan(NULL) => <nil>, want Error")
+	}
+}
+
+func TestNullVersionSQL(t *testing.T) {
+	ver, _ := semver.New("1.2.3-beta")
+	var result semver.NullVersion
+	if err := echo(t, semver.NullVersion{Version: *ver, Valid: true}, &result); err != nil {
+		t.Errorf("Scan(%q) => %v, want <nil>", ver, err)
+	}
+	if !result.Valid || result.Version.String() != ver.String() {
+		t.Errorf("Scan(%q) => %v, want %q", ver, result, ver)
+	}
+
+	if err := echo(t, semver.NullVersion{}, &result); err != nil {
+		t.Errorf("Scan(NULL) => %v, want <nil>", err)
+	}
+	if result.Valid {
+		t.Errorf("Scan(NULL) => %v, want invalid", result)
+	}
+}
+
+func TestRangeSQL(t *testing.T) {
+	for input, expected := range rangeStrings {
+		r := semver.MustParseRange(input)
+		var result semver.Range
+		if err := echo(t, *r, &result); err != nil {
+			t.Errorf("Scan(Value(%q)) => %v, want <nil>", r, err)
+			continue
+		}
+		if result.String() != expected || result.Sugared() != r.Sugared() {
+			t.Errorf("Scan(Value(%q)) => %q, want %q", r, result.Sugared(), r.Sugared())
+		}
+	}
+
+	// The range is stored as written, so metadata and shorthands survive, whatever the options.
+	for _, input := range []string{"^1.2.3", "1.2.3+build", "~1.2.3 || 2.x"} {
+		for _, o := range []semver.Option{0, semver.IncludePrerelease} {
+			var result semver.Range
+			if err := echo(t, semver.MustParseRange(input, o), &result); err != nil || result.Sugared() != input {
+				t.Errorf("Scan(Value(%q)) with options %d => %q, %v, want %q, <nil>", input, o, result.Sugared(), err, input)
+			}
+		}
+	}
+
+	var result semver.Range
+	if err := echo(t, []byte("~ 1.2.3"), &result); err == nil {
+		t.Errorf("Scan(%q) => <nil>, want Error", "~ 1.2.3")
+	}
+}
+
+func TestNullRangeSQL(t *testing.T) {
+	var result semver.NullRange
+	if err := echo(t, "^1.2.3", &result); err != nil {
+		t.Errorf("Scan(%q) => %v, want <nil>", "^1.2.3", err)
+	}
+	if !result.Valid || !result.Range.Check(semver.Build(1, 5, 0)) {
+		t.Errorf("Scan(%q) => %v, want valid range", "^1.2.3", result)
+	}
+
+	if err := echo(t, semver.NullRange{}, &result); err != nil {
+		t.Errorf("Scan(NULL) => %v, want <nil>", err)
+	}
+	if result.Valid {
+		t.Errorf("Scan(NULL) => %v, want invalid", result)
+	}
+}
@@ -0,0 +1,60 @@
+package semver
+
+import "sort"
+
+// Subset reports whether every version matching sub also matches super, like npm's semver.subset.
+// Disjoint comparator sets in super may cover sub together, and prereleases match as they would
+// in Check, so ">=1.0.0-0" is not a subset of ">=1.0.0" unless super has IncludePrerelease.
+func Subset(sub, super *Range) bool {
+	for _, v := range samples(sub.root.(nodeRange), super.root.(nodeRange)) {
+		if sub.Check(v) && !super.Check(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// samples returns one version from each stretch of versions that the comparators of ranges can tell apart.
+// Those are the comparator versions, the first prerelease and release of each one, and their successors.
+func samples(ranges ...nodeRange) Versions {
+	bounds := Versions{{prerelease: "0"}, {}}
+	for _, r := range ranges {
+		for _, set := range r.sets {
+			for _, c := range set {
+				v := c.arg.release()
+				if c.arg.isPrerelease() {
+					first, pre := v, v
+					first.prerelease, pre.prerelease = "0", c.arg.prerelease
+					bounds = append(bounds, &first, &pre)
+				}
+				bounds = append(bounds, &v)
+			}
+		}
+	}
+	sort.Sort(bounds)
+
+	var result Versions
+	for i, v := range bounds {
+		if i > 0 && v.Equal(bounds[i-1]) {
+			continue
+		}
+		result = append(result, v)
+
+		next := []*Version{v.successor()}
+		if !v.isPrerelease() {
+			release := next[0].release()
+			next = append(next, &release)
+		}
+		// The following bound is the first one greater than v.
+		j := i + 1
+		for j < len(bounds) && bounds[j].Equal(v) {
+			j++
+		}
+		for _, n := range next {
+			if j == len(bounds) || n.Compare(bounds[j]) < 0 {
+				result = append(result, n)
+			}
+		}
+	}
+	return result
+}
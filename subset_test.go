@@ -0,0 +1,92 @@
+package semver_test
+
+import (
+	"testing"
+
+	"github.com/hansrodtang/semver"
+)
+
+type subset struct {
+	sub, super string
+	options    semver.Option
+	expected   bool
+}
+
+// Cases partly adapted from the test fixtures of npm's semver.subset.
+var subsets = []subset{
+	{"1.2.3", "1.2.3", 0, true},
+	{"1.2.3", "1.x", 0, true},
+	{"1.2.3", "1.2.3 || 2.x", 0, true},
+	{"1.2.3", "*", 0, true},
+	{"1.2.3", "*", semver.IncludePrerelease, true},
+	{"^1.2.3", "1.x", 0, true},
+	{"1.x", "^1.2.3", 0, false},
+	{">2.0.0", ">1.2.3", 0, true},
+	{">1.2.3", ">2.0.0", 0, false},
+	{">=1.2.3 <2.0.0", "^1.2.3", 0, true},
+	{"<1.0.0", "<2.0.0", 0, true},
+	{"<2.0.0", ">=1.0.0", 0, false},
+	{"*", "*", 0, true},
+	{"", "1.2.3", 0, false},
+	{"1.2.3", "", 0, true},
+	{"*", " ", 0, true},
+	{"<0.0.0-0", "1.2.3", 0, true},
+	{"~1.2.3", "~1.2.3 || >=3.0.0", 0, true},
+	{"2.x || 4.x", "<3.0.0 || >=4.0.0", 0, true},
+	{"2.x || 4.x", "<3.0.0 || >=4.1.0", 0, false},
+
+	// Disjoint sets of super may cover sub together.
+	{"1.x", "1.0.x || >=1.1.0 <2.0.0-0", 0, true},
+	{">=1.2.3 <2.0.0", "<1.5.0 || >=1.5.0", 0, true},
+	{">=1.0.0", "1.x || >=2.0.0", 0, true},
+	{">=1.0.0", "1.x || >2.0.0", 0, false},
+	{"1.x || >=2.0.0", ">=1.0.0", 0, true},
+
+	// Prereleases only match comparators on the same version, unless included.
+	{"1.2.3-pre.0", ">=1.2.3-pre.0 <1.2.3-pre.5", 0, true},
+	{"^1.2.3-pre.0", "^1.2.3-pre.0", 0, true},
+	{">1.2.3-pre.0", ">=1.2.3", 0, false},
+	{"=1.2.3-pre.0", ">=1.2.3", 0, false},
+	{">=1.0.0-0", ">=1.0.0", 0, false},
+	{">=1.0.0", ">=1.0.0-0", 0, true},
+	{">=0.0.0-0", "*", 0, false},
+	{"*", ">=0.0.0-0", 0, true},
+	{"^1.2.3-beta", ">=1.2.3-alpha <2.0.0", 0, true},
+	{"^1.2.3-beta", ">=1.2.3-alpha <2.0.0", semver.IncludePrerelease, true},
+	{">=1.2.3-beta <2.0.0", ">=1.2.3-alpha <2.0.0-0", 0, true},
+	{">=1.2.3-beta <2.0.0", ">=1.2.3-alpha <2.0.0-0", semver.IncludePrerelease, false},
+	{"*", ">=0.0.0-0", semver.IncludePrerelease, true},
+	{">=0.0.0-0", "*", semver.IncludePrerelease, true},
+	{"<1.0.0", "<1.0.0-0", 0, true},
+	{"<1.0.0", "<1.0.0-0", semver.IncludePrerelease, false},
+}
+
+func TestSubset(t *testing.T) {
+	for _, c := range subsets {
+		sub, super := semver.MustParseRange(c.sub, c.options), semver.MustParseRange(c.super, c.options)
+		if result := semver.Subset(sub, super); result != c.expected {
+			t.Errorf("Subset(%q, %q) with options %d => %t, want %t", sub, super, c.options, result, c.expected)
+		}
+	}
+}
+
+// TestSubsetMatches checks that no version matching a subset misses the superset.
+func TestSubsetMatches(t *testing.T) {
+	versions := matchVersions()
+	eachMatchPair(func(sub, super matchRange) {
+		if !semver.Subset(sub.Range, super.Range) {
+			return
+		}
+		for _, v := range versions {
+			if sub.Check(v) && !super.Check(v) {
+				t.Errorf("Subset(%q, %q) with options %d, %d => true, but only %q matches %q", sub, super, sub.options, super.options, sub, v)
+			}
+		}
+	})
+	for _, input := range intersectRanges {
+		r := semver.MustParseRange(input)
+		if !semver.Subset(r, r) || !semver.Subset(semver.Intersect(r, r), r) {
+			t.Errorf("Subset(%q, %q) => false, want true", r, r)
+		}
+	}
+}
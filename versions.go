@@ -0,0 +1,146 @@
+package semver
+
+import (
+	"errors"
+	"sort"
+)
+
+// Versions implements sort.Interface, ordering versions by Compare.
+// Versions of equal precedence may end up in any order, use SortStable to order them by their metadata.
+type Versions []*Version
+
+func (v Versions) Len() int {
+	return len(v)
+}
+
+func (v Versions) Swap(i, j int) {
+	v[i], v[j] = v[j], v[i]
+}
+
+func (v Versions) Less(i, j int) bool {
+	return v[i].Compare(v[j]) < 0
+}
+
+// SortStable sorts v in place by CompareBuild, so versions of equal precedence are ordered by their
+// metadata and the result doesn't depend on the original order.
+func (v Versions) SortStable() {
+	sort.SliceStable(v, func(i, j int) bool {
+		return v[i].CompareBuild(v[j]) < 0
+	})
+}
+
+// Reverse reverses the order of v in place.
+func (v Versions) Reverse() {
+	for i, j := 0, len(v)-1; i < j; i, j = i+1, j-1 {
+		v[i], v[j] = v[j], v[i]
+	}
+}
+
+// Max returns the highest version in v, or nil if v is empty.
+// Of versions with the same precedence, the first one is returned.
+func (v Versions) Max() *Version {
+	var max *Version
+	for _, ver := range v {
+		if max == nil || ver.Compare(max) > 0 {
+			max = ver
+		}
+	}
+	return max
+}
+
+// Min returns the lowest version in v, or nil if v is empty.
+// Of versions with the same precedence, the first one is returned.
+func (v Versions) Min() *Version {
+	var min *Version
+	for _, ver := range v {
+		if min == nil || ver.Compare(min) < 0 {
+			min = ver
+		}
+	}
+	return min
+}
+
+// LatestStable returns the highest version in v that isn't a prerelease, or nil if there is none.
+func (v Versions) LatestStable() *Version {
+	var latest *Version
+	for _, ver := range v {
+		if !ver.isPrerelease() && (latest == nil || ver.Compare(latest) > 0) {
+			latest = ver
+		}
+	}
+	return latest
+}
+
+// Dedup returns the versions of v without those with the same precedence as an earlier one,
+// so like Contains and Index it ignores metadata.
+func (v Versions) Dedup() Versions {
+	seen := make(map[Version]bool, len(v))
+	result := make(Versions, 0, len(v))
+	for _, ver := range v {
+		key := *ver
+		key.metadata = ""
+		if !seen[key] {
+			seen[key] = true
+			result = append(result, ver)
+		}
+	}
+	return result
+}
+
+// Contains reports whether v holds a version with the same precedence as ver.
+func (v Versions) Contains(ver *Version) bool {
+	for _, other := range v {
+		if other.Equal(ver) {
+			return true
+		}
+	}
+	return false
+}
+
+// Index returns the index of a version with the same precedence as ver, or -1 if there is none.
+// v must be sorted in ascending order, as done by sort.Sort or SortStable, so it can be searched in O(log n).
+func (v Versions) Index(ver *Version) int {
+	i := sort.Search(len(v), func(i int) bool {
+		return v[i].Compare(ver) >= 0
+	})
+	if i < len(v) && v[i].Equal(ver) {
+		return i
+	}
+	return -1
+}
+
+// GroupBy groups the versions of v by their major version if level is Major or PreMajor, by their
+// major and minor version if level is Minor or PreMinor, and by all three numbers otherwise.
+// The groups are keyed by that release without the rest, such as 1.2.0 for 1.2.3-rc.1 and Minor,
+// and keep the order of v.
+func (v Versions) GroupBy(level Release) map[Version]Versions {
+	groups := make(map[Version]Versions)
+	for _, ver := range v {
+		key := ver.release()
+		switch level {
+		case Major, PreMajor:
+			key.setNumber(1, 0)
+			key.setNumber(2, 0)
+		case Minor, PreMinor:
+			key.setNumber(2, 0)
+		}
+		groups[key] = append(groups[key], ver)
+	}
+	return groups
+}
+
+// ParseVersions parses each of the supplied strings with New. It returns the versions that could be
+// parsed in their original order, along with the errors of those that couldn't joined into one.
+func ParseVersions(versions []string) (Versions, error) {
+	result := make(Versions, 0, len(versions))
+	var errs []error
+	for _, version := range versions {
+		ver, err := New(version)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		result = append(result, ver)
+	}
+	return result, errors.Join(errs...)
+}
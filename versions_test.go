@@ -0,0 +1,201 @@
+package semver
+
+import (
+	"errors"
+	"sort"
+	"testing"
+)
+
+var unsortedVersions = []string{
+	"1.2.3-alpha.2+123.456",
+	"1.2.3-alpha.1",
+	"1.4.3+123.456",
+	"1.3.3-alpha.b-eta+123.b-uild",
+	"1.2.3+123.b-uild",
+	"1.2.3-alpha.b-eta",
+	"0.0.1-alpha.preview+123.456",
+}
+
+var sortedVersions = []string{
+	"0.0.1-alpha.preview+123.456",
+	"1.2.3-alpha.1",
+	"1.2.3-alpha.2+123.456",
+	"1.2.3-alpha.b-eta",
+	"1.2.3+123.b-uild",
+	"1.3.3-alpha.b-eta+123.b-uild",
+	"1.4.3+123.456",
+}
+
+func TestSorter(t *testing.T) {
+	var unsortedlist Versions
+	for _, v := range unsortedVersions {
+		ver, _ := New(v)
+		unsortedlist = append(unsortedlist, ver)
+	}
+
+	sort.Sort(unsortedlist)
+
+	var sortedlist Versions
+	for _, v := range sortedVersions {
+		ver, _ := New(v)
+		sortedlist = append(sortedlist, ver)
+	}
+
+	for i, r := range unsortedlist {
+		result := r.String()
+		expected := sortedlist[i].String()
+
+		if result != expected {
+			t.Errorf("sort.Sort() => %q, want %v", result, expected)
+		}
+	}
+
+}
+
+func TestSortStable(t *testing.T) {
+	expected := []string{"1.0.0", "1.0.0+1", "1.0.0+2", "1.0.0+a", "1.0.0+b", "1.1.0-rc"}
+	for _, order := range [][]int{{5, 4, 3, 2, 1, 0}, {3, 0, 4, 1, 5, 2}, {0, 1, 2, 3, 4, 5}} {
+		var list Versions
+		for _, i := range order {
+			ver, _ := New(expected[i])
+			list = append(list, ver)
+		}
+		list.SortStable()
+		for i, ver := range list {
+			if ver.String() != expected[i] {
+				t.Errorf("SortStable() => %v, want %v", list, expected)
+				break
+			}
+		}
+	}
+}
+
+func parseAll(t *testing.T, versions ...string) Versions {
+	list, err := ParseVersions(versions)
+	if err != nil {
+		t.Fatalf("ParseVersions(%q) => %v, want <nil>", versions, err)
+	}
+	return list
+}
+
+func TestMaxMin(t *testing.T) {
+	list := parseAll(t, "1.2.3", "2.0.0-rc.1", "1.10.0", "0.9.0+build", "2.0.0-beta", "0.9.0")
+	if result := list.Max().String(); result != "2.0.0-rc.1" {
+		t.Errorf("Max() => %q, want %q", result, "2.0.0-rc.1")
+	}
+	if result := list.Min().String(); result != "0.9.0+build" {
+		t.Errorf("Min() => %q, want %q", result, "0.9.0+build")
+	}
+	if result := list.LatestStable().String(); result != "1.10.0" {
+		t.Errorf("LatestStable() => %q, want %q", result, "1.10.0")
+	}
+
+	var empty Versions
+	if empty.Max() != nil || empty.Min() != nil || parseAll(t, "1.0.0-rc").LatestStable() != nil {
+		t.Errorf("Max(), Min(), LatestStable() of no versions => %v, %v, %v, want <nil>",
+			empty.Max(), empty.Min(), parseAll(t, "1.0.0-rc").LatestStable())
+	}
+}
+
+func TestDedup(t *testing.T) {
+	list := parseAll(t, "1.0.0+a", "1.0.0+b", "2.0.0", "1.0.0", "2.0.0-rc")
+	result := list.Dedup()
+	if len(result) != 3 || result[0] != list[0] || result[1] != list[2] || result[2] != list[4] {
+		t.Errorf("Dedup() => %v, want %v", result, Versions{list[0], list[2], list[4]})
+	}
+	if len(list) != 5 {
+		t.Errorf("Dedup() changed the receiver to %v", list)
+	}
+}
+
+func TestReverse(t *testing.T) {
+	for _, versions := range [][]string{{}, {"1.0.0"}, {"1.0.0", "2.0.0"}, {"1.0.0", "2.0.0", "3.0.0"}} {
+		list := parseAll(t, versions...)
+		list.Reverse()
+		for i, ver := range list {
+			if ver.String() != versions[len(versions)-1-i] {
+				t.Errorf("Reverse() of %q => %v", versions, list)
+				break
+			}
+		}
+	}
+}
+
+func TestContainsIndex(t *testing.T) {
+	list := parseAll(t, "0.1.0", "1.0.0-rc.1", "1.0.0+build", "1.2.3", "2.0.0")
+	for i, ver := range list {
+		if result := list.Index(ver); result != i {
+			t.Errorf("Index(%q) => %d, want %d", ver, result, i)
+		}
+		if !list.Contains(ver) {
+			t.Errorf("Contains(%q) => false, want true", ver)
+		}
+	}
+	present, _ := New("1.0.0")
+	if result := list.Index(present); result != 2 || !list.Contains(present) {
+		t.Errorf("Index(%q), Contains => %d, %t, want 2, true", present, result, list.Contains(present))
+	}
+	for _, version := range []string{"0.0.1", "1.0.0-rc.2", "1.2.4", "3.0.0"} {
+		missing, _ := New(version)
+		if result := list.Index(missing); result != -1 || list.Contains(missing) {
+			t.Errorf("Index(%q), Contains => %d, %t, want -1, false", missing, result, list.Contains(missing))
+		}
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	list := parseAll(t, "1.2.3", "1.3.0-rc.1", "2.0.0", "1.2.4", "1.2.3+build", "99999999999999999999.1.0")
+	groups := []struct {
+		level    Release
+		expected map[string][]string
+	}{
+		{Major, map[string][]string{
+			"1.0.0":                    {"1.2.3", "1.3.0-rc.1", "1.2.4", "1.2.3+build"},
+			"2.0.0":                    {"2.0.0"},
+			"99999999999999999999.0.0": {"99999999999999999999.1.0"},
+		}},
+		{Minor, map[string][]string{
+			"1.2.0":                    {"1.2.3", "1.2.4", "1.2.3+build"},
+			"1.3.0":                    {"1.3.0-rc.1"},
+			"2.0.0":                    {"2.0.0"},
+			"99999999999999999999.1.0": {"99999999999999999999.1.0"},
+		}},
+		{Patch, map[string][]string{
+			"1.2.3":                    {"1.2.3", "1.2.3+build"},
+			"1.2.4":                    {"1.2.4"},
+			"1.3.0":                    {"1.3.0-rc.1"},
+			"2.0.0":                    {"2.0.0"},
+			"99999999999999999999.1.0": {"99999999999999999999.1.0"},
+		}},
+	}
+	for _, g := range groups {
+		result := list.GroupBy(g.level)
+		if len(result) != len(g.expected) {
+			t.Errorf("GroupBy(%v) => %v, want %v", g.level, result, g.expected)
+			continue
+		}
+		for key, versions := range result {
+			expected := g.expected[key.String()]
+			if len(versions) != len(expected) {
+				t.Errorf("GroupBy(%v)[%q] => %v, want %v", g.level, key, versions, expected)
+				continue
+			}
+			for i, ver := range versions {
+				if ver.String() != expected[i] {
+					t.Errorf("GroupBy(%v)[%q] => %v, want %v", g.level, key, versions, expected)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestParseVersions(t *testing.T) {
+	list, err := ParseVersions([]string{"1.0.0", "1.0", "2.0.0", "1.02.0"})
+	if len(list) != 2 || list[0].String() != "1.0.0" || list[1].String() != "2.0.0" {
+		t.Errorf("ParseVersions() => %v, want [1.0.0 2.0.0]", list)
+	}
+	if !errors.Is(err, ErrMissingComponent) || !errors.Is(err, ErrLeadingZero) {
+		t.Errorf("ParseVersions() => %v, want %v and %v", err, ErrMissingComponent, ErrLeadingZero)
+	}
+}